@@ -0,0 +1,159 @@
+// Command graphql-enum (built from cmd/graphql-enum) drives the
+// generator/traverser/tui stack in
+// internal/, as opposed to the standalone enumerator in the repository's
+// root main.go.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lovepreet-se7en/graphql-enum/internal/complexity"
+	"github.com/lovepreet-se7en/graphql-enum/internal/schema"
+	"github.com/lovepreet-se7en/graphql-enum/internal/traverser"
+	"github.com/lovepreet-se7en/graphql-enum/tui"
+)
+
+func main() {
+	var (
+		schemaFile        = flag.String("schema", "", "SDL schema file, glob (schemas/*.graphql), or comma-separated list of either")
+		introspect        = flag.String("introspect", "", "Live GraphQL endpoint to introspect instead of --schema")
+		headerFlags       headerList
+		targetType        = flag.String("type", "", "Target type to find paths to")
+		maxDepth          = flag.Int("max-depth", 15, "Maximum traversal depth")
+		includeMutations  = flag.Bool("mutations", false, "Include mutation fields as entry points")
+		parallel          = flag.Bool("parallel", false, "Use the parallel traverser")
+		run               = flag.String("run", "", "Endpoint to run generated queries against live, inside the TUI")
+		relay             = flag.Bool("relay", false, "Treat Relay connection fields as a transparent edges.node hop with cursor pagination")
+		check             = flag.Bool("check", false, "Report duplicate/conflicting definitions across --schema files instead of enumerating")
+		includeDeprecated = flag.Bool("include-deprecated", false, "Include fields marked @deprecated in generated queries")
+		emitConditional   = flag.Bool("emit-conditional", false, "Emit @include/@skip directives (and matching Boolean variables) on non-terminal path segments")
+		requireDirective  = flag.String("require-directive", "", "Only traverse fields carrying this directive, e.g. auth")
+		excludeDirective  = flag.String("exclude-directive", "", "Never traverse fields carrying this directive, e.g. internal")
+		maxComplexity     = flag.Int("max-complexity", 0, "Abandon a branch once its accumulated field cost exceeds this budget (0 disables)")
+		complexityConfig  = flag.String("complexity-config", "", "YAML file overriding per-field cost, e.g. complexity: { User.repositories: 10 }")
+	)
+	flag.Var(&headerFlags, "header", `Header to send with --introspect or --run, as "Name: Value" (repeatable)`)
+	flag.Parse()
+
+	if *schemaFile == "" && *introspect == "" {
+		fmt.Fprintln(os.Stderr, "Error: one of --schema or --introspect is required")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if *check {
+		if *schemaFile == "" {
+			fmt.Fprintln(os.Stderr, "Error: --check requires --schema")
+			os.Exit(1)
+		}
+		conflicts, err := schema.Check(*schemaFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(conflicts) == 0 {
+			fmt.Println("No conflicts found.")
+			return
+		}
+		for _, c := range conflicts {
+			fmt.Println(c)
+		}
+		os.Exit(1)
+	}
+
+	if *targetType == "" {
+		fmt.Fprintln(os.Stderr, "Error: --type is required")
+		os.Exit(1)
+	}
+
+	headers := headerFlags.toMap()
+
+	var (
+		scm *schema.Schema
+		err error
+	)
+	if *introspect != "" {
+		scm, err = schema.LoadFromIntrospection(*introspect, headers)
+	} else {
+		scm, err = schema.Load(*schemaFile)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !scm.TypeExists(*targetType) {
+		fmt.Fprintf(os.Stderr, "Error: type %q not found in schema\n", *targetType)
+		if similar := scm.FindSimilarTypes(*targetType); len(similar) > 0 {
+			fmt.Fprintf(os.Stderr, "Did you mean: %s?\n", strings.Join(similar, ", "))
+		}
+		os.Exit(1)
+	}
+
+	entryPoints := scm.GetEntryPoints(*includeMutations)
+
+	costs, err := complexity.LoadConfig(*complexityConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading complexity config: %v\n", err)
+		os.Exit(1)
+	}
+
+	var paths []schema.GraphQLPath
+	if *parallel {
+		t := traverser.NewParallel(scm, *maxDepth, 0).WithRelay(*relay).
+			WithDirectiveFilter(false, *requireDirective, *excludeDirective).
+			WithComplexityBudget(*maxComplexity, costs)
+		paths = t.FindPaths(entryPoints, *targetType)
+	} else {
+		t := traverser.NewSequential(scm, *maxDepth).WithRelay(*relay).
+			WithDirectiveFilter(false, *requireDirective, *excludeDirective).
+			WithComplexityBudget(*maxComplexity, costs)
+		paths = t.FindPaths(entryPoints, *targetType)
+	}
+
+	sort.SliceStable(paths, func(i, j int) bool { return paths[i].Complexity < paths[j].Complexity })
+
+	if len(paths) == 0 {
+		fmt.Printf("No paths found to %s within depth limit (%d)\n", *targetType, *maxDepth)
+		os.Exit(2)
+	}
+
+	model := tui.NewModel(paths, scm, *targetType, *relay, *includeDeprecated, *emitConditional)
+	if *run != "" {
+		model.SetEndpoint(*run, headers)
+	}
+
+	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// headerList collects repeated -header "Name: Value" flags.
+type headerList []string
+
+func (h *headerList) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerList) Set(value string) error {
+	*h = append(*h, value)
+	return nil
+}
+
+func (h *headerList) toMap() map[string]string {
+	headers := make(map[string]string, len(*h))
+	for _, raw := range *h {
+		parts := strings.SplitN(raw, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers
+}
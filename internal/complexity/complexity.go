@@ -0,0 +1,71 @@
+// Package complexity scores GraphQL fields so traversers can bound
+// enumeration by accumulated cost instead of a fixed depth, the way
+// gqlgen's complexity analysis bounds query execution.
+package complexity
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/lovepreet-se7en/graphql-enum/internal/schema"
+	"gopkg.in/yaml.v3"
+)
+
+// fanoutMultiplier is used for a first/last/limit argument whose default
+// value isn't declared in the schema, so a bound still applies.
+const fanoutMultiplier = 10
+
+// Config maps "TypeName.fieldName" to a fixed cost override, loaded from a
+// YAML file shaped like:
+//
+//	complexity:
+//	  User.repositories: 10
+type Config map[string]int
+
+// LoadConfig reads a complexity override file. An empty path returns an
+// empty Config, so overrides are entirely optional.
+func LoadConfig(path string) (Config, error) {
+	if path == "" {
+		return Config{}, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read complexity config: %w", err)
+	}
+
+	var parsed struct {
+		Complexity map[string]int `yaml:"complexity"`
+	}
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse complexity config: %w", err)
+	}
+
+	return Config(parsed.Complexity), nil
+}
+
+// FieldCost scores following field from a parent of typeName: base cost 1,
+// overridden by a "TypeName.fieldName" entry in c, multiplied by the value
+// of a first/last/limit argument (or fanoutMultiplier when that argument is
+// present without a known default).
+func (c Config) FieldCost(typeName string, field schema.Field) int {
+	base := 1
+	if override, ok := c[typeName+"."+field.Name]; ok {
+		base = override
+	}
+
+	for _, arg := range field.Args {
+		if arg.Name != "first" && arg.Name != "last" && arg.Name != "limit" {
+			continue
+		}
+		if arg.DefaultValue != nil {
+			if v, err := strconv.Atoi(*arg.DefaultValue); err == nil {
+				return base * v
+			}
+		}
+		return base * fanoutMultiplier
+	}
+
+	return base
+}
@@ -45,9 +45,12 @@ func ToJSON(paths []schema.GraphQLPath, targetType, schemaFile string) ([]byte,
 	entryPoints := make(map[string]bool)
 	
 	for i, path := range paths {
-		segments := make([]string, len(path.Segments))
+		var segments []string
 		for j, seg := range path.Segments {
-			segments[j] = seg.Name
+			segments = append(segments, seg.Name)
+			if seg.Fragment != "" {
+				segments = append(segments, "... on "+seg.Fragment)
+			}
 			if j == 0 {
 				entryPoints[seg.Name] = true
 			}
@@ -97,6 +100,9 @@ func formatPath(path schema.GraphQLPath) string {
 	var parts []string
 	for _, seg := range path.Segments {
 		parts = append(parts, seg.Name)
+		if seg.Fragment != "" {
+			parts = append(parts, "... on "+seg.Fragment)
+		}
 	}
 	// Simple join with arrows for readability
 	return joinParts(parts, " â†’ ")
@@ -0,0 +1,22 @@
+package exporter
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/lovepreet-se7en/graphql-enum/internal/runner"
+)
+
+// RunReportToJSON serializes a RunReport for external consumption.
+func RunReportToJSON(report *runner.RunReport) ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// SaveRunReport writes a RunReport's JSON form to filename.
+func SaveRunReport(report *runner.RunReport, filename string) error {
+	data, err := RunReportToJSON(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
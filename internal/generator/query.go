@@ -11,17 +11,21 @@ import (
 )
 
 type Generator struct {
-	schema    *schema.Schema
-	outputDir string
+	schema            *schema.Schema
+	outputDir         string
+	relay             bool
+	includeDeprecated bool
+	emitConditional   bool
 }
 
 type GeneratedQuery struct {
-	Index       int                    `json:"index"`
-	Description string                 `json:"description"`
-	Path        string                 `json:"path"`
-	Query       string                 `json:"query"`
-	Variables   map[string]interface{} `json:"variables"`
-	FileName    string                 `json:"file_name"`
+	Index             int                    `json:"index"`
+	Description       string                 `json:"description"`
+	Path              string                 `json:"path"`
+	Query             string                 `json:"query"`
+	Variables         map[string]interface{} `json:"variables"`
+	FileName          string                 `json:"file_name"`
+	SkippedDeprecated []string               `json:"skipped_deprecated,omitempty"`
 }
 
 func New(s *schema.Schema, outputDir string) *Generator {
@@ -31,6 +35,30 @@ func New(s *schema.Schema, outputDir string) *Generator {
 	}
 }
 
+// WithRelay toggles Relay connection rendering: edges { cursor node { ... } }
+// and pageInfo selections instead of the plain 2-leaf connection heuristic.
+func (g *Generator) WithRelay(relay bool) *Generator {
+	g.relay = relay
+	return g
+}
+
+// WithIncludeDeprecated toggles whether addLeafFields selects fields marked
+// @deprecated. By default they're skipped to keep generated queries off
+// fields a server may remove.
+func (g *Generator) WithIncludeDeprecated(include bool) *Generator {
+	g.includeDeprecated = include
+	return g
+}
+
+// WithEmitConditional toggles @include($<seg>_include) / @skip($<seg>_skip)
+// directives on every non-terminal path segment, with matching Boolean
+// variables, so callers can toggle hops in/out of a generated query without
+// regenerating it.
+func (g *Generator) WithEmitConditional(emit bool) *Generator {
+	g.emitConditional = emit
+	return g
+}
+
 func (g *Generator) GenerateAll(paths []schema.GraphQLPath) ([]GeneratedQuery, error) {
 	queries := make([]GeneratedQuery, len(paths))
 	
@@ -43,13 +71,14 @@ func (g *Generator) GenerateAll(paths []schema.GraphQLPath) ([]GeneratedQuery, e
 
 func (g *Generator) generateOne(path schema.GraphQLPath, index int) GeneratedQuery {
 	var (
-		queryBuilder strings.Builder
-		vars         = make(map[string]interface{})
-		varDefs      []string
+		queryBuilder         strings.Builder
+		vars                 = make(map[string]interface{})
+		varDefs              []string
+		allSkippedDeprecated []string
 	)
 	
 	// Collect variables
-	for _, seg := range path.Segments {
+	for i, seg := range path.Segments {
 		for _, arg := range seg.Args {
 			varName := fmt.Sprintf("%s_%s", seg.Name, arg.Name)
 			vars[varName] = g.generateExampleValue(arg.Type)
@@ -57,6 +86,16 @@ func (g *Generator) generateOne(path schema.GraphQLPath, index int) GeneratedQue
 				varDefs = append(varDefs, fmt.Sprintf("$%s: %s", varName, arg.Type))
 			}
 		}
+
+		if g.emitConditional && i < len(path.Segments)-1 {
+			includeVar := fmt.Sprintf("%s_include", seg.Name)
+			skipVar := fmt.Sprintf("%s_skip", seg.Name)
+			vars[includeVar] = true
+			vars[skipVar] = false
+			varDefs = append(varDefs,
+				fmt.Sprintf("$%s: Boolean", includeVar),
+				fmt.Sprintf("$%s: Boolean", skipVar))
+		}
 	}
 	
 	// Build query header
@@ -66,11 +105,16 @@ func (g *Generator) generateOne(path schema.GraphQLPath, index int) GeneratedQue
 	}
 	queryBuilder.WriteString(" {\n")
 	
-	// Build body
+	// Build body. closeStack records, innermost-last, the indent each open
+	// brace must be closed at. A plain segment contributes one level; a
+	// segment reached through an abstract field adds a second for its
+	// "... on ConcreteType { }" inline fragment; a Relay connection segment
+	// adds two more for its "edges { node { } }" wrapper.
 	indent := "  "
+	var closeStack []string
 	for i, seg := range path.Segments {
 		queryBuilder.WriteString(indent + seg.Name)
-		
+
 		// Add arguments
 		if len(seg.Args) > 0 {
 			var argParts []string
@@ -81,76 +125,119 @@ func (g *Generator) generateOne(path schema.GraphQLPath, index int) GeneratedQue
 			}
 			queryBuilder.WriteString("(" + strings.Join(argParts, ", ") + ")")
 		}
-		
-		if i < len(path.Segments)-1 {
-			queryBuilder.WriteString(" {\n")
+
+		if g.emitConditional && i < len(path.Segments)-1 {
+			queryBuilder.WriteString(fmt.Sprintf(" @include(if: $%s_include) @skip(if: $%s_skip)", seg.Name, seg.Name))
+		}
+
+		queryBuilder.WriteString(" {\n")
+		closeStack = append(closeStack, indent)
+		indent += "  "
+
+		if seg.Relay {
+			queryBuilder.WriteString(indent + "pageInfo { hasNextPage endCursor hasPreviousPage startCursor }\n")
+			queryBuilder.WriteString(indent + "edges {\n")
+			closeStack = append(closeStack, indent)
 			indent += "  "
-		} else {
-			// Last segment - add fields
+			queryBuilder.WriteString(indent + "cursor\n")
+			queryBuilder.WriteString(indent + "node {\n")
+			closeStack = append(closeStack, indent)
+			indent += "  "
+		}
+
+		if seg.Fragment != "" {
+			queryBuilder.WriteString(indent + "... on " + seg.Fragment)
 			queryBuilder.WriteString(" {\n")
-			g.addLeafFields(&queryBuilder, indent+"  ", seg.Type)
-			queryBuilder.WriteString("\n" + indent + "}")
+			closeStack = append(closeStack, indent)
+			indent += "  "
 		}
+
+		var skippedDeprecated []string
+		if i == len(path.Segments)-1 {
+			// Last segment - add leaf fields for whichever concrete type was chosen
+			skippedDeprecated = g.addLeafFields(&queryBuilder, indent, seg.Type)
+		}
+		allSkippedDeprecated = append(allSkippedDeprecated, skippedDeprecated...)
 	}
-	
-	// Close braces
-	for i := 0; i < len(path.Segments)-1; i++ {
-		indent = indent[:len(indent)-2]
-		queryBuilder.WriteString("\n" + indent + "}")
+
+	for i := len(closeStack) - 1; i >= 0; i-- {
+		queryBuilder.WriteString("\n" + closeStack[i] + "}")
 	}
-	
+
 	queryBuilder.WriteString("\n}")
-	
+
 	pathStr := g.formatPath(path)
-	
+
 	return GeneratedQuery{
-		Index:       index,
-		Description: fmt.Sprintf("Path %d: %s", index, pathStr),
-		Path:        pathStr,
-		Query:       queryBuilder.String(),
-		Variables:   vars,
-		FileName:    fmt.Sprintf("query_%03d.graphql", index),
+		Index:             index,
+		Description:       fmt.Sprintf("Path %d: %s", index, pathStr),
+		Path:              pathStr,
+		Query:             queryBuilder.String(),
+		Variables:         vars,
+		FileName:          fmt.Sprintf("query_%03d.graphql", index),
+		SkippedDeprecated: allSkippedDeprecated,
 	}
 }
 
-func (g *Generator) addLeafFields(b *strings.Builder, indent, typeName string) {
+// addLeafFields writes the leaf selection set for typeName and returns the
+// names of any @deprecated fields it skipped (when g.includeDeprecated is
+// false), so callers can surface what was left out of the generated query.
+func (g *Generator) addLeafFields(b *strings.Builder, indent, typeName string) []string {
 	b.WriteString(indent + "__typename")
 
 	typ := g.schema.GetType(typeName)
 	if typ == nil {
-		return
+		return nil
 	}
 
+	var skipped []string
 	count := 0
 	for _, f := range typ.Fields {
+		if !g.includeDeprecated && f.IsDeprecated() {
+			skipped = append(skipped, f.Name)
+			continue
+		}
 		if f.Type != typeName { // Avoid recursion
 			b.WriteString("\n" + indent + f.Name)
 
 			// Check if this field is a connection type that needs subselections
 			if g.isConnectionType(f.Type) {
-				b.WriteString(" {")
-				// Add basic fields for connection types
-				b.WriteString("\n" + indent + "  __typename")
-
-				// If it's a connection, try to get the node type and add some fields
 				nodeType := g.getNodeType(f.Type)
-				if nodeType != "" {
-					nodeTyp := g.schema.GetType(nodeType)
-					if nodeTyp != nil {
-						// Add a few basic fields from the node type
-						fieldCount := 0
-						for _, nodeField := range nodeTyp.Fields {
-							if len(nodeField.Args) == 0 && nodeField.Name != "__typename" {
-								b.WriteString("\n" + indent + "  " + nodeField.Name)
-								fieldCount++
-								if fieldCount >= 2 { // Limit to 2 fields to avoid overly complex queries
-									break
+
+				if g.relay {
+					b.WriteString(" {")
+					b.WriteString("\n" + indent + "  pageInfo { hasNextPage endCursor hasPreviousPage startCursor }")
+					b.WriteString("\n" + indent + "  edges {")
+					b.WriteString("\n" + indent + "    cursor")
+					b.WriteString("\n" + indent + "    node {")
+					b.WriteString("\n" + indent + "      __typename")
+					b.WriteString("\n" + indent + "    }")
+					b.WriteString("\n" + indent + "  }")
+					b.WriteString("\n" + indent + "}")
+				} else {
+					b.WriteString(" {")
+					// Add basic fields for connection types
+					b.WriteString("\n" + indent + "  __typename")
+
+					// If it's a connection, try to get the node type and add some fields
+					if nodeType != "" {
+						nodeTyp := g.schema.GetType(nodeType)
+						if nodeTyp != nil {
+							// Add a few basic fields from the node type
+							fieldCount := 0
+							for _, nodeField := range nodeTyp.Fields {
+								if len(nodeField.Args) == 0 && nodeField.Name != "__typename" {
+									b.WriteString("\n" + indent + "  " + nodeField.Name)
+									fieldCount++
+									if fieldCount >= 2 { // Limit to 2 fields to avoid overly complex queries
+										break
+									}
 								}
 							}
 						}
 					}
+					b.WriteString("\n" + indent + "}")
 				}
-				b.WriteString("\n" + indent + "}")
 			}
 
 			count++
@@ -159,6 +246,8 @@ func (g *Generator) addLeafFields(b *strings.Builder, indent, typeName string) {
 			}
 		}
 	}
+
+	return skipped
 }
 
 // isConnectionType checks if a type is a connection type (ends with Connection)
@@ -213,7 +302,11 @@ func (g *Generator) getBaseTypeName(typeName string) string {
 func (g *Generator) formatPath(path schema.GraphQLPath) string {
 	var parts []string
 	for _, seg := range path.Segments {
-		parts = append(parts, seg.Name)
+		if seg.Fragment != "" {
+			parts = append(parts, fmt.Sprintf("%s → ... on %s", seg.Name, seg.Fragment))
+		} else {
+			parts = append(parts, seg.Name)
+		}
 	}
 	return strings.Join(parts, " → ")
 }
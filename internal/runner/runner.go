@@ -0,0 +1,242 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lovepreet-se7en/graphql-enum/internal/generator"
+)
+
+// Config controls how the Runner executes queries against a live endpoint.
+type Config struct {
+	Endpoint    string
+	Headers     map[string]string
+	Concurrency int
+	RatePerSec  float64 // 0 disables rate limiting
+	MaxRetries  int
+	RetryDelay  time.Duration
+	Timeout     time.Duration
+}
+
+// GraphQLError mirrors a single entry of a GraphQL response's top-level "errors" array.
+type GraphQLError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+type graphqlRequestBody struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+type graphqlResponseBody struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+// Result is the outcome of executing a single GeneratedQuery.
+type Result struct {
+	Index         int             `json:"index"`
+	Path          string          `json:"path"`
+	Status        int             `json:"status"`
+	LatencyMs     int64           `json:"latency_ms"`
+	Response      json.RawMessage `json:"response,omitempty"`
+	Errors        []GraphQLError  `json:"errors,omitempty"`
+	TargetReached bool            `json:"target_reached"`
+	TransportErr  string          `json:"transport_error,omitempty"`
+	Attempts      int             `json:"attempts"`
+}
+
+// RunReport collects the results of running every GeneratedQuery against an endpoint.
+type RunReport struct {
+	Endpoint    string   `json:"endpoint"`
+	TargetType  string   `json:"target_type"`
+	GeneratedAt string   `json:"generated_at"`
+	Total       int      `json:"total"`
+	Reached     int      `json:"reached"`
+	Results     []Result `json:"results"`
+}
+
+// Runner executes GeneratedQuery batches against a live GraphQL endpoint.
+type Runner struct {
+	cfg    Config
+	client *http.Client
+}
+
+// New builds a Runner, applying sane defaults for any zero-valued Config fields.
+func New(cfg Config) *Runner {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = 500 * time.Millisecond
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	return &Runner{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// RunAll executes every query concurrently (bounded by cfg.Concurrency) and
+// collects the responses into a RunReport.
+func (r *Runner) RunAll(queries []generator.GeneratedQuery, targetType string) *RunReport {
+	var (
+		results = make([]Result, len(queries))
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, r.cfg.Concurrency)
+		limiter = r.newLimiter()
+	)
+
+	for i, q := range queries {
+		wg.Add(1)
+		go func(i int, q generator.GeneratedQuery) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			if limiter != nil {
+				<-limiter
+			}
+
+			results[i] = r.runOne(i+1, q, targetType)
+		}(i, q)
+	}
+
+	wg.Wait()
+
+	report := &RunReport{
+		Endpoint:    r.cfg.Endpoint,
+		TargetType:  targetType,
+		GeneratedAt: time.Now().Format(time.RFC3339),
+		Total:       len(results),
+		Results:     results,
+	}
+	for _, res := range results {
+		if res.TargetReached {
+			report.Reached++
+		}
+	}
+	return report
+}
+
+// newLimiter returns a channel that yields a token at most RatePerSec times a
+// second, or nil when rate limiting is disabled.
+func (r *Runner) newLimiter() <-chan time.Time {
+	if r.cfg.RatePerSec <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / r.cfg.RatePerSec)
+	return time.Tick(interval)
+}
+
+func (r *Runner) runOne(index int, q generator.GeneratedQuery, targetType string) Result {
+	result := Result{Index: index, Path: q.Path}
+
+	body, err := json.Marshal(graphqlRequestBody{
+		Query:     q.Query,
+		Variables: q.Variables,
+	})
+	if err != nil {
+		result.TransportErr = fmt.Sprintf("encode request: %v", err)
+		return result
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= r.cfg.MaxRetries+1; attempt++ {
+		result.Attempts = attempt
+
+		start := time.Now()
+		status, respBody, err := r.post(body)
+		result.LatencyMs = time.Since(start).Milliseconds()
+
+		if err != nil {
+			lastErr = err
+			if r.shouldRetry(status, err) && attempt <= r.cfg.MaxRetries {
+				time.Sleep(r.backoff(attempt))
+				continue
+			}
+			result.TransportErr = err.Error()
+			return result
+		}
+
+		if r.shouldRetry(status, nil) && attempt <= r.cfg.MaxRetries {
+			lastErr = fmt.Errorf("server returned status %d", status)
+			time.Sleep(r.backoff(attempt))
+			continue
+		}
+
+		result.Status = status
+		var parsed graphqlResponseBody
+		if jsonErr := json.Unmarshal(respBody, &parsed); jsonErr == nil {
+			result.Response = parsed.Data
+			result.Errors = parsed.Errors
+		} else {
+			result.Response = respBody
+		}
+		result.TargetReached = responseReachesType(respBody, targetType)
+		return result
+	}
+
+	result.TransportErr = lastErr.Error()
+	return result
+}
+
+func (r *Runner) post(body []byte) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodPost, r.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range r.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, err
+	}
+	return resp.StatusCode, data, nil
+}
+
+// shouldRetry treats connection-level failures and 5xx responses as transient.
+func (r *Runner) shouldRetry(status int, err error) bool {
+	if status >= 500 {
+		return true
+	}
+	return status == 0 && err != nil
+}
+
+func (r *Runner) backoff(attempt int) time.Duration {
+	return r.cfg.RetryDelay * time.Duration(attempt)
+}
+
+// responseReachesType does a cheap scan of the raw response body for a
+// "__typename":"<targetType>" marker, which is the only reliable way to tell
+// whether the target type was actually reached without re-parsing against
+// the schema.
+func responseReachesType(body []byte, targetType string) bool {
+	if targetType == "" {
+		return false
+	}
+	marker := []byte(fmt.Sprintf(`"__typename":"%s"`, targetType))
+	markerSpaced := []byte(fmt.Sprintf(`"__typename": "%s"`, targetType))
+	return bytes.Contains(body, marker) || bytes.Contains(body, markerSpaced)
+}
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Directive represents a GraphQL directive applied to a field or argument,
+// e.g. @deprecated(reason: "use newField instead") or @auth(requires: ADMIN).
+type Directive struct {
+	Name string
+	Args map[string]string
+}
+
+var directiveRe = regexp.MustCompile(`@(\w+)(?:\(([^)]*)\))?`)
+
+// parseDirectives extracts every @directive(...) occurrence from raw (the
+// remainder of an SDL field line after its type).
+func parseDirectives(raw string) []Directive {
+	var directives []Directive
+	for _, m := range directiveRe.FindAllStringSubmatch(raw, -1) {
+		d := Directive{Name: m[1]}
+		if m[2] != "" {
+			d.Args = make(map[string]string)
+			for _, pair := range strings.Split(m[2], ",") {
+				kv := strings.SplitN(pair, ":", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				key := strings.TrimSpace(kv[0])
+				val := strings.Trim(strings.TrimSpace(kv[1]), `"`)
+				d.Args[key] = val
+			}
+		}
+		directives = append(directives, d)
+	}
+	return directives
+}
+
+// HasDirective reports whether name appears among directives.
+func HasDirective(directives []Directive, name string) bool {
+	for _, d := range directives {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeprecated reports whether f carries @deprecated.
+func (f Field) IsDeprecated() bool {
+	return HasDirective(f.Directives, "deprecated")
+}
+
+// DeprecationReason returns the reason argument of @deprecated, if any.
+func (f Field) DeprecationReason() string {
+	for _, d := range f.Directives {
+		if d.Name == "deprecated" {
+			return d.Args["reason"]
+		}
+	}
+	return ""
+}
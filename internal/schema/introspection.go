@@ -0,0 +1,231 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IntrospectionQuery is the standard document used to fetch a schema from a
+// live GraphQL endpoint.
+const IntrospectionQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      kind
+      name
+      interfaces { name }
+      possibleTypes { name }
+      fields(includeDeprecated: true) {
+        name
+        args { name type { ...TypeRef } }
+        type { ...TypeRef }
+        isDeprecated
+        deprecationReason
+      }
+    }
+  }
+}
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type introspectionTypeRef struct {
+	Kind   string                 `json:"kind"`
+	Name   string                 `json:"name"`
+	OfType *introspectionTypeRef  `json:"ofType"`
+}
+
+type introspectionArg struct {
+	Name string                `json:"name"`
+	Type introspectionTypeRef  `json:"type"`
+}
+
+type introspectionField struct {
+	Name              string               `json:"name"`
+	Args              []introspectionArg   `json:"args"`
+	Type              introspectionTypeRef `json:"type"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason string               `json:"deprecationReason"`
+}
+
+type introspectionNamedRef struct {
+	Name string `json:"name"`
+}
+
+type introspectionType struct {
+	Kind          string                  `json:"kind"`
+	Name          string                  `json:"name"`
+	Interfaces    []introspectionNamedRef `json:"interfaces"`
+	PossibleTypes []introspectionNamedRef `json:"possibleTypes"`
+	Fields        []introspectionField    `json:"fields"`
+}
+
+type introspectionResponse struct {
+	Data struct {
+		Schema struct {
+			QueryType        *introspectionNamedRef `json:"queryType"`
+			MutationType     *introspectionNamedRef `json:"mutationType"`
+			SubscriptionType *introspectionNamedRef `json:"subscriptionType"`
+			Types            []introspectionType    `json:"types"`
+		} `json:"__schema"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// LoadFromIntrospection fetches a schema from a live GraphQL endpoint using
+// the standard introspection query and builds the same *Schema structure the
+// SDL-based Load produces, so the existing traversers work unchanged.
+func LoadFromIntrospection(endpoint string, headers map[string]string) (*Schema, error) {
+	body, err := json.Marshal(map[string]string{"query": IntrospectionQuery})
+	if err != nil {
+		return nil, fmt.Errorf("encode introspection request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build introspection request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspection request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read introspection response: %w", err)
+	}
+
+	var parsed introspectionResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("decode introspection response: %w", err)
+	}
+	if len(parsed.Errors) > 0 {
+		return nil, fmt.Errorf("introspection query failed: %s", parsed.Errors[0].Message)
+	}
+	if parsed.Data.Schema.QueryType == nil {
+		return nil, fmt.Errorf("introspection response has no queryType")
+	}
+
+	return buildFromIntrospection(&parsed), nil
+}
+
+func buildFromIntrospection(parsed *introspectionResponse) *Schema {
+	root := parsed.Data.Schema
+
+	s := &Schema{
+		Types:     make(map[string]*Type),
+		QueryType: root.QueryType.Name,
+	}
+	if root.MutationType != nil {
+		s.MutationType = root.MutationType.Name
+	}
+
+	for _, t := range root.Types {
+		if strings.HasPrefix(t.Name, "__") {
+			continue
+		}
+
+		typ := &Type{
+			Name: t.Name,
+			Kind: t.Kind,
+		}
+		for _, i := range t.Interfaces {
+			typ.Interfaces = append(typ.Interfaces, i.Name)
+		}
+		for _, pt := range t.PossibleTypes {
+			typ.PossibleTypes = append(typ.PossibleTypes, pt.Name)
+		}
+
+		for _, f := range t.Fields {
+			field := Field{
+				Name: f.Name,
+				Type: BaseTypeName(formatTypeRef(f.Type)),
+			}
+			if f.IsDeprecated {
+				field.Directives = append(field.Directives, Directive{
+					Name: "deprecated",
+					Args: map[string]string{"reason": f.DeprecationReason},
+				})
+			}
+			for _, a := range f.Args {
+				typeStr := formatTypeRef(a.Type)
+				field.Args = append(field.Args, Arg{
+					Name:     a.Name,
+					Type:     typeStr,
+					Required: strings.HasSuffix(typeStr, "!"),
+				})
+			}
+			typ.Fields = append(typ.Fields, field)
+		}
+
+		s.Types[t.Name] = typ
+	}
+
+	return s
+}
+
+// formatTypeRef walks the NON_NULL/LIST wrapper chain of an introspection
+// type reference and renders it as the SDL-style string (e.g. "[User!]!")
+// that the rest of the package (BaseTypeName and friends) expects.
+func formatTypeRef(t introspectionTypeRef) string {
+	switch t.Kind {
+	case "NON_NULL":
+		if t.OfType == nil {
+			return ""
+		}
+		return formatTypeRef(*t.OfType) + "!"
+	case "LIST":
+		if t.OfType == nil {
+			return ""
+		}
+		return "[" + formatTypeRef(*t.OfType) + "]"
+	default:
+		return t.Name
+	}
+}
@@ -0,0 +1,181 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	typeBlockRe      = regexp.MustCompile(`(?s)type\s+(\w+)\s*(?:implements\s+([\w\s&]+?))?\s*\{(.*?)\}`)
+	extendTypeRe     = regexp.MustCompile(`(?s)extend\s+type\s+(\w+)\s*\{(.*?)\}`)
+	interfaceBlockRe = regexp.MustCompile(`(?s)interface\s+(\w+)\s*\{(.*?)\}`)
+	unionDeclRe      = regexp.MustCompile(`union\s+(\w+)\s*=\s*([\w\s|]+)`)
+	schemaDeclRe     = regexp.MustCompile(`(?s)schema\s*\{(.*?)\}`)
+	fieldLineRe      = regexp.MustCompile(`^(\w+)\s*(\([^)]*\))?\s*:\s*(\[?[\w!\]]+)(.*)$`)
+	argLineRe        = regexp.MustCompile(`(\w+)\s*:\s*(\[?[\w!\]]+)`)
+)
+
+// Load reads one or more SDL (.graphql) files and merges them into a single
+// *Schema. spec may be a single file path, a glob (e.g. "schemas/*.graphql"),
+// or a comma-separated list of either.
+func Load(spec string) (*Schema, error) {
+	files, err := expandSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no schema files matched %q", spec)
+	}
+
+	merger := newMerger()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read schema file: %w", err)
+		}
+		if err := merger.addFile(file, string(data)); err != nil {
+			return nil, err
+		}
+	}
+
+	return merger.finish()
+}
+
+// Check loads spec the same way as Load but only reports duplicate/conflicting
+// definitions, never building a traversable schema. A nil error with no
+// conflicts means the merged schema is well-formed.
+func Check(spec string) ([]string, error) {
+	files, err := expandSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	merger := newMerger()
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read schema file: %w", err)
+		}
+		if err := merger.addFile(file, string(data)); err != nil {
+			// addFile only returns hard parse errors; conflicts are
+			// accumulated in merger.conflicts so --check can report all
+			// of them instead of stopping at the first.
+			return nil, err
+		}
+	}
+	if err := merger.applyExtends(); err != nil {
+		return nil, err
+	}
+
+	return merger.conflicts, nil
+}
+
+// expandSpec turns a --schema argument into a sorted, deduplicated file list.
+// Each comma-separated entry is resolved as a glob (a plain path is its own
+// 1-element glob match).
+func expandSpec(spec string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		matches, err := filepath.Glob(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema glob %q: %w", entry, err)
+		}
+		if matches == nil {
+			// Not a glob pattern (or no wildcard matched) - treat as a literal path.
+			matches = []string{entry}
+		}
+		for _, m := range matches {
+			if !seen[m] {
+				seen[m] = true
+				files = append(files, m)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// parseSDL parses a single file's contents in isolation, without merging it
+// with any other file. Load does not use it; Load always goes through the
+// multi-file merger, even for a single file.
+func parseSDL(src string) (*Schema, error) {
+	m := newMerger()
+	if err := m.addFile("<string>", src); err != nil {
+		return nil, err
+	}
+	return m.finish()
+}
+
+func parseSchemaDecl(src string, s *Schema) {
+	if m := schemaDeclRe.FindStringSubmatch(src); m != nil {
+		for _, line := range strings.Split(m[1], "\n") {
+			line = strings.TrimSpace(line)
+			parts := strings.SplitN(line, ":", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			op := strings.TrimSpace(parts[0])
+			name := strings.TrimSpace(parts[1])
+			switch op {
+			case "query":
+				s.QueryType = name
+			case "mutation":
+				s.MutationType = name
+			}
+		}
+	}
+}
+
+func parseFields(body string) []Field {
+	var fields []Field
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fm := fieldLineRe.FindStringSubmatch(line)
+		if fm == nil {
+			continue
+		}
+		field := Field{Name: fm[1], Type: BaseTypeName(fm[3]), Directives: parseDirectives(fm[4])}
+		if fm[2] != "" {
+			argsBody := strings.Trim(fm[2], "()")
+			for _, am := range argLineRe.FindAllStringSubmatch(argsBody, -1) {
+				field.Args = append(field.Args, Arg{
+					Name:     am[1],
+					Type:     am[2],
+					Required: strings.HasSuffix(am[2], "!"),
+				})
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+func stripComments(src string) string {
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lineOf returns the 1-based line number at byte offset in src.
+func lineOf(src string, offset int) int {
+	if offset < 0 || offset > len(src) {
+		return 0
+	}
+	return strings.Count(src[:offset], "\n") + 1
+}
@@ -0,0 +1,182 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fieldOrigin records where a field definition came from, for conflict
+// reporting.
+type fieldOrigin struct {
+	file string
+	line int
+}
+
+// pendingExtend records an `extend type` block whose base type may not have
+// been declared yet, so it can be applied once every file has been scanned.
+type pendingExtend struct {
+	file string
+	name string
+	body string
+	line int
+}
+
+// merger accumulates types across multiple SDL files, unioning fields by
+// name and flagging conflicting re-declarations.
+type merger struct {
+	schema    *Schema
+	origins   map[string]map[string]fieldOrigin // type name -> field name -> origin
+	conflicts []string
+	extends   []pendingExtend
+}
+
+func newMerger() *merger {
+	return &merger{
+		schema: &Schema{
+			Types:        make(map[string]*Type),
+			QueryType:    "Query",
+			MutationType: "Mutation",
+		},
+		origins: make(map[string]map[string]fieldOrigin),
+	}
+}
+
+func (m *merger) addFile(file, rawSrc string) error {
+	src := stripComments(rawSrc)
+
+	parseSchemaDecl(src, m.schema)
+
+	for _, loc := range typeBlockRe.FindAllStringSubmatchIndex(src, -1) {
+		// typeBlockRe also matches the "type X {" inside "extend type X {";
+		// those blocks are left to the extend pass below.
+		if strings.HasSuffix(strings.TrimSpace(src[:loc[0]]), "extend") {
+			continue
+		}
+		name := src[loc[2]:loc[3]]
+		var implements string
+		if loc[4] >= 0 {
+			implements = src[loc[4]:loc[5]]
+		}
+		body := src[loc[6]:loc[7]]
+		line := lineOf(rawSrc, loc[0])
+
+		typ := m.schema.Types[name]
+		if typ == nil {
+			typ = &Type{Name: name, Kind: "OBJECT"}
+			m.schema.Types[name] = typ
+		}
+		if strings.TrimSpace(implements) != "" {
+			for _, iface := range strings.Split(implements, "&") {
+				typ.Interfaces = append(typ.Interfaces, strings.TrimSpace(iface))
+			}
+		}
+		m.mergeFields(typ, parseFields(body), file, line)
+	}
+
+	// extend blocks are resolved once every file has been added (see
+	// applyExtends), since a base type may live in a file processed later.
+	for _, loc := range extendTypeRe.FindAllStringSubmatchIndex(src, -1) {
+		m.extends = append(m.extends, pendingExtend{
+			file: file,
+			name: src[loc[2]:loc[3]],
+			body: src[loc[4]:loc[5]],
+			line: lineOf(rawSrc, loc[0]),
+		})
+	}
+
+	for _, m2 := range interfaceBlockRe.FindAllStringSubmatch(src, -1) {
+		name, body := m2[1], m2[2]
+		if existing, ok := m.schema.Types[name]; ok && existing.Kind != "INTERFACE" {
+			m.conflicts = append(m.conflicts, fmt.Sprintf("%s: interface %s conflicts with earlier non-interface definition of the same name", file, name))
+			continue
+		}
+		typ := m.schema.Types[name]
+		if typ == nil {
+			typ = &Type{Name: name, Kind: "INTERFACE"}
+			m.schema.Types[name] = typ
+		}
+		m.mergeFields(typ, parseFields(body), file, 0)
+	}
+
+	for _, m2 := range unionDeclRe.FindAllStringSubmatch(src, -1) {
+		name, membersRaw := m2[1], m2[2]
+		var members []string
+		for _, member := range strings.Split(membersRaw, "|") {
+			members = append(members, strings.TrimSpace(member))
+		}
+		m.schema.Types[name] = &Type{Name: name, Kind: "UNION", PossibleTypes: members}
+	}
+
+	return nil
+}
+
+// mergeFields unions newFields into typ.Fields, recording a conflict
+// (without stopping at the first one; finish turns any recorded conflicts
+// into an error) when a field already exists with a different type
+// signature.
+func (m *merger) mergeFields(typ *Type, newFields []Field, file string, line int) {
+	typeOrigins, ok := m.origins[typ.Name]
+	if !ok {
+		typeOrigins = make(map[string]fieldOrigin)
+		m.origins[typ.Name] = typeOrigins
+	}
+
+	existing := make(map[string]*Field, len(typ.Fields))
+	for i := range typ.Fields {
+		existing[typ.Fields[i].Name] = &typ.Fields[i]
+	}
+
+	for _, f := range newFields {
+		if prev, ok := existing[f.Name]; ok {
+			if prev.Type != f.Type {
+				origin := typeOrigins[f.Name]
+				m.conflicts = append(m.conflicts, fmt.Sprintf(
+					"%s:%d: field %s.%s (%s) conflicts with %s:%d (%s)",
+					file, line, typ.Name, f.Name, f.Type, origin.file, origin.line, prev.Type))
+			}
+			continue
+		}
+		typ.Fields = append(typ.Fields, f)
+		existing[f.Name] = &typ.Fields[len(typ.Fields)-1]
+		typeOrigins[f.Name] = fieldOrigin{file: file, line: line}
+	}
+}
+
+// applyExtends merges every `extend type` block collected across all added
+// files, now that every base type/interface declaration has been seen
+// regardless of which file defined it.
+func (m *merger) applyExtends() error {
+	for _, ext := range m.extends {
+		typ := m.schema.Types[ext.name]
+		if typ == nil {
+			return fmt.Errorf("%s:%d: extend type %s references undefined type", ext.file, ext.line, ext.name)
+		}
+		m.mergeFields(typ, parseFields(ext.body), ext.file, ext.line)
+	}
+	return nil
+}
+
+func (m *merger) finish() (*Schema, error) {
+	if err := m.applyExtends(); err != nil {
+		return nil, err
+	}
+
+	// Back-fill PossibleTypes on interfaces from the implementing objects,
+	// so the traverser can branch into each concrete type.
+	for _, typ := range m.schema.Types {
+		for _, ifaceName := range typ.Interfaces {
+			if iface, ok := m.schema.Types[ifaceName]; ok {
+				iface.PossibleTypes = append(iface.PossibleTypes, typ.Name)
+			}
+		}
+	}
+
+	if _, ok := m.schema.Types[m.schema.QueryType]; !ok {
+		return nil, fmt.Errorf("schema has no %s type", m.schema.QueryType)
+	}
+	if len(m.conflicts) > 0 {
+		return nil, fmt.Errorf("conflicting schema definitions:\n%s", strings.Join(m.conflicts, "\n"))
+	}
+
+	return m.schema, nil
+}
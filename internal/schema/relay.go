@@ -0,0 +1,45 @@
+package schema
+
+import "strings"
+
+// BaseTypeName strips GraphQL's NON_NULL (!) and LIST ([...]) modifiers,
+// leaving the underlying named type. Mirrors generator.getBaseTypeName so
+// both packages agree on what a field's "real" type is.
+func BaseTypeName(typeName string) string {
+	result := strings.TrimSuffix(typeName, "!")
+	if strings.HasPrefix(result, "[") && strings.HasSuffix(result, "]") {
+		result = strings.Trim(result, "[]!")
+	}
+	return result
+}
+
+// IsConnectionType reports whether typeName follows the Relay connection
+// naming convention (e.g. "RepositoryConnection").
+func (s *Schema) IsConnectionType(typeName string) bool {
+	return strings.HasSuffix(BaseTypeName(typeName), "Connection")
+}
+
+// ConnectionNodeType resolves a Relay connection type to the type of its
+// `edges.node` field, or "" if connectionType isn't a recognizable
+// connection.
+func (s *Schema) ConnectionNodeType(connectionType string) string {
+	connTyp := s.GetType(BaseTypeName(connectionType))
+	if connTyp == nil {
+		return ""
+	}
+	for _, field := range connTyp.Fields {
+		if field.Name != "edges" {
+			continue
+		}
+		edgeTyp := s.GetType(BaseTypeName(field.Type))
+		if edgeTyp == nil {
+			return ""
+		}
+		for _, edgeField := range edgeTyp.Fields {
+			if edgeField.Name == "node" {
+				return BaseTypeName(edgeField.Type)
+			}
+		}
+	}
+	return ""
+}
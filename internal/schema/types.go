@@ -4,24 +4,34 @@ import "strings"
 
 // Arg represents a GraphQL argument
 type Arg struct {
-	Name         string  `json:"name"`
-	Type         string  `json:"type"`
-	Required     bool    `json:"required"`
-	DefaultValue *string `json:"defaultValue"`
+	Name         string      `json:"name"`
+	Type         string      `json:"type"`
+	Required     bool        `json:"required"`
+	DefaultValue *string     `json:"defaultValue"`
+	Directives   []Directive `json:"directives,omitempty"`
 }
 
 // Field represents a GraphQL field
 type Field struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
-	Args []Arg  `json:"args"`
+	Name       string      `json:"name"`
+	Type       string      `json:"type"`
+	Args       []Arg       `json:"args"`
+	Directives []Directive `json:"directives,omitempty"`
 }
 
 // Type represents a GraphQL type
 type Type struct {
-	Name   string  `json:"name"`
-	Kind   string  `json:"kind"`
-	Fields []Field `json:"fields"`
+	Name          string   `json:"name"`
+	Kind          string   `json:"kind"` // OBJECT, INTERFACE, UNION, ...
+	Fields        []Field  `json:"fields"`
+	Interfaces    []string `json:"interfaces,omitempty"`    // names of interfaces this type implements
+	PossibleTypes []string `json:"possibleTypes,omitempty"` // concrete types, when Kind is INTERFACE or UNION
+}
+
+// IsAbstract reports whether t is an interface or union, i.e. a field typed
+// as t can resolve to one of several concrete types at runtime.
+func (t *Type) IsAbstract() bool {
+	return t.Kind == "INTERFACE" || t.Kind == "UNION"
 }
 
 // EntryPoint represents a Query or Mutation entry point
@@ -36,12 +46,21 @@ type PathSegment struct {
 	Name string
 	Type string
 	Args []Arg
+	// Fragment is set when this segment was reached through an abstract
+	// (interface/union) field, naming the concrete type the traverser chose
+	// to branch into, e.g. "User" (rendered as "... on User").
+	Fragment string
+	// Relay is set when this segment was reached through a Relay connection
+	// field; Type names the resolved edges.node type rather than the
+	// connection type itself, so downstream traversal continues unchanged.
+	Relay bool
 }
 
 // GraphQLPath represents a complete path from entry to target
 type GraphQLPath struct {
-	Segments []PathSegment
-	Depth    int
+	Segments   []PathSegment
+	Depth      int
+	Complexity int // accumulated field cost along Segments, see internal/complexity
 }
 
 // Schema holds the GraphQL schema
@@ -0,0 +1,27 @@
+package traverser
+
+import "github.com/lovepreet-se7en/graphql-enum/internal/schema"
+
+// directiveFilter prunes fields by the directives declared on them, letting
+// callers do authorization-aware enumeration (e.g. only follow fields behind
+// @auth, or never follow fields marked @internal). The zero value allows
+// everything.
+type directiveFilter struct {
+	skipDeprecated   bool
+	requireDirective string
+	excludeDirective string
+}
+
+// allows reports whether field should be descended into under this filter.
+func (f directiveFilter) allows(field schema.Field) bool {
+	if f.skipDeprecated && field.IsDeprecated() {
+		return false
+	}
+	if f.requireDirective != "" && !schema.HasDirective(field.Directives, f.requireDirective) {
+		return false
+	}
+	if f.excludeDirective != "" && schema.HasDirective(field.Directives, f.excludeDirective) {
+		return false
+	}
+	return true
+}
@@ -3,13 +3,18 @@ package traverser
 import (
 	"sync"
 
+	"github.com/lovepreet-se7en/graphql-enum/internal/complexity"
 	"github.com/lovepreet-se7en/graphql-enum/internal/schema"
 )
 
 type Parallel struct {
-	schema     *schema.Schema
-	maxDepth   int
-	workers    int
+	schema        *schema.Schema
+	maxDepth      int
+	workers       int
+	relay         bool
+	filter        directiveFilter
+	maxComplexity int
+	costs         complexity.Config
 }
 
 type job struct {
@@ -17,6 +22,7 @@ type job struct {
 	path        []schema.PathSegment
 	visited     map[string]bool
 	depth       int
+	score       int
 }
 
 func NewParallel(s *schema.Schema, maxDepth, workers int) *Parallel {
@@ -30,6 +36,38 @@ func NewParallel(s *schema.Schema, maxDepth, workers int) *Parallel {
 	}
 }
 
+// WithRelay toggles Relay connection traversal: fields typed as a Relay
+// connection become a transparent edges.node hop instead of a dead end.
+func (p *Parallel) WithRelay(relay bool) *Parallel {
+	p.relay = relay
+	return p
+}
+
+// WithDirectiveFilter prunes fields before the traverser ever descends into
+// them: skipDeprecated drops @deprecated fields, requireDirective keeps only
+// fields carrying that directive, excludeDirective drops fields carrying it.
+// Pass "" to leave require/exclude unset.
+func (p *Parallel) WithDirectiveFilter(skipDeprecated bool, requireDirective, excludeDirective string) *Parallel {
+	p.filter = directiveFilter{
+		skipDeprecated:   skipDeprecated,
+		requireDirective: requireDirective,
+		excludeDirective: excludeDirective,
+	}
+	return p
+}
+
+// WithComplexityBudget bounds traversal by accumulated field cost instead of
+// the fixed "only recurse while depth < 5" heuristic: a branch is abandoned
+// once following it would push the path's score past maxComplexity.
+// maxComplexity <= 0 disables the budget, restoring the old depth-5 cutoff.
+// costs supplies per-field overrides; pass complexity.Config{} for
+// cost-1-per-field defaults.
+func (p *Parallel) WithComplexityBudget(maxComplexity int, costs complexity.Config) *Parallel {
+	p.maxComplexity = maxComplexity
+	p.costs = costs
+	return p
+}
+
 func (p *Parallel) FindPaths(entryPoints []schema.EntryPoint, targetType string) []schema.GraphQLPath {
 	var (
 		paths      []schema.GraphQLPath
@@ -58,80 +96,204 @@ func (p *Parallel) FindPaths(entryPoints []schema.EntryPoint, targetType string)
 	for _, ep := range entryPoints {
 		visited := map[string]bool{ep.Type: true}
 		initialPath := []schema.PathSegment{{Name: ep.Name, Type: ep.Type, Args: ep.Args}}
-		
+
 		if ep.Type == targetType {
 			paths = append(paths, schema.GraphQLPath{
-				Segments: initialPath,
-				Depth:    1,
+				Segments:   initialPath,
+				Depth:      1,
+				Complexity: 1,
 			})
 		}
-		
+
 		jobs <- job{
 			currentType: ep.Type,
 			path:        initialPath,
 			visited:     visited,
 			depth:       1,
+			score:       1,
 		}
 	}
 
 	close(jobs)
 	wg.Wait()
-	
+
 	return paths
 }
 
+// withinBudget reports whether score may still recurse deeper: either a
+// complexity budget is configured and score hasn't exceeded it, or (with no
+// budget configured) depth hasn't passed the old fixed fan-out cutoff.
+func (p *Parallel) withinBudget(score, depth int) bool {
+	if p.maxComplexity > 0 {
+		return score <= p.maxComplexity
+	}
+	return depth < 5
+}
+
 func (p *Parallel) processJob(j job, targetType string) []schema.GraphQLPath {
 	if j.depth >= p.maxDepth {
 		return nil
 	}
-	
+
 	typ := p.schema.GetType(j.currentType)
 	if typ == nil {
 		return nil
 	}
-	
+
 	var results []schema.GraphQLPath
-	
+
 	for _, field := range typ.Fields {
 		if j.visited[field.Type] {
 			continue
 		}
-		
+		if !p.filter.allows(field) {
+			continue
+		}
+
+		newScore := j.score + p.costs.FieldCost(j.currentType, field)
+		if p.maxComplexity > 0 && newScore > p.maxComplexity {
+			continue
+		}
+
+		if p.relay && p.schema.IsConnectionType(field.Type) {
+			results = append(results, p.processConnectionField(j, field, newScore, targetType)...)
+			continue
+		}
+
+		fieldTyp := p.schema.GetType(field.Type)
+		if fieldTyp != nil && fieldTyp.IsAbstract() {
+			results = append(results, p.processAbstractField(j, fieldTyp, field, newScore, targetType)...)
+			continue
+		}
+
 		newSegment := schema.PathSegment{
 			Name: field.Name,
 			Type: field.Type,
 			Args: field.Args,
 		}
-		
+
 		newPath := make([]schema.PathSegment, len(j.path))
 		copy(newPath, j.path)
 		newPath = append(newPath, newSegment)
-		
+
 		if field.Type == targetType {
 			results = append(results, schema.GraphQLPath{
-				Segments: newPath,
-				Depth:    j.depth + 1,
+				Segments:   newPath,
+				Depth:      j.depth + 1,
+				Complexity: newScore,
 			})
 			continue
 		}
-		
-		// For parallel execution, only go deeper if depth is small to avoid explosion
-		if j.depth < 5 {
+
+		if p.withinBudget(newScore, j.depth) {
 			newVisited := make(map[string]bool)
 			for k, v := range j.visited {
 				newVisited[k] = v
 			}
 			newVisited[field.Type] = true
-			
+
 			subResults := p.processJob(job{
 				currentType: field.Type,
 				path:        newPath,
 				visited:     newVisited,
 				depth:       j.depth + 1,
+				score:       newScore,
 			}, targetType)
 			results = append(results, subResults...)
 		}
 	}
-	
+
+	return results
+}
+
+// processConnectionField treats a Relay connection field as a transparent
+// hop into its edges.node type, synthesizing first/after pagination
+// variables and tagging the resulting segment as Relay.
+func (p *Parallel) processConnectionField(j job, field schema.Field, score int, targetType string) []schema.GraphQLPath {
+	nodeType := p.schema.ConnectionNodeType(field.Type)
+	if nodeType == "" || j.visited[nodeType] {
+		return nil
+	}
+
+	newSegment := schema.PathSegment{
+		Name:  field.Name,
+		Type:  nodeType,
+		Args:  withPaginationArgs(field.Args),
+		Relay: true,
+	}
+	newPath := make([]schema.PathSegment, len(j.path))
+	copy(newPath, j.path)
+	newPath = append(newPath, newSegment)
+
+	if nodeType == targetType {
+		return []schema.GraphQLPath{{Segments: newPath, Depth: j.depth + 1, Complexity: score}}
+	}
+
+	if !p.withinBudget(score, j.depth) {
+		return nil
+	}
+
+	newVisited := make(map[string]bool)
+	for k, v := range j.visited {
+		newVisited[k] = v
+	}
+	newVisited[nodeType] = true
+
+	return p.processJob(job{
+		currentType: nodeType,
+		path:        newPath,
+		visited:     newVisited,
+		depth:       j.depth + 1,
+		score:       score,
+	}, targetType)
+}
+
+// processAbstractField mirrors processJob's recursion but branches into each
+// concrete type a field's interface/union can resolve to, tagging the
+// resulting path segment with Fragment.
+func (p *Parallel) processAbstractField(j job, abstractTyp *schema.Type, field schema.Field, score int, targetType string) []schema.GraphQLPath {
+	var results []schema.GraphQLPath
+
+	for _, concrete := range abstractTyp.PossibleTypes {
+		if j.visited[concrete] {
+			continue
+		}
+
+		newSegment := schema.PathSegment{
+			Name:     field.Name,
+			Type:     concrete,
+			Args:     field.Args,
+			Fragment: concrete,
+		}
+		newPath := make([]schema.PathSegment, len(j.path))
+		copy(newPath, j.path)
+		newPath = append(newPath, newSegment)
+
+		if concrete == targetType {
+			results = append(results, schema.GraphQLPath{
+				Segments:   newPath,
+				Depth:      j.depth + 1,
+				Complexity: score,
+			})
+			continue
+		}
+
+		if p.withinBudget(score, j.depth) {
+			newVisited := make(map[string]bool)
+			for k, v := range j.visited {
+				newVisited[k] = v
+			}
+			newVisited[concrete] = true
+
+			results = append(results, p.processJob(job{
+				currentType: concrete,
+				path:        newPath,
+				visited:     newVisited,
+				depth:       j.depth + 1,
+				score:       score,
+			}, targetType)...)
+		}
+	}
+
 	return results
 }
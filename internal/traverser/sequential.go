@@ -1,12 +1,17 @@
 package traverser
 
 import (
+	"github.com/lovepreet-se7en/graphql-enum/internal/complexity"
 	"github.com/lovepreet-se7en/graphql-enum/internal/schema"
 )
 
 type Sequential struct {
-	schema   *schema.Schema
-	maxDepth int
+	schema        *schema.Schema
+	maxDepth      int
+	relay         bool
+	filter        directiveFilter
+	maxComplexity int
+	costs         complexity.Config
 }
 
 func NewSequential(s *schema.Schema, maxDepth int) *Sequential {
@@ -16,67 +21,209 @@ func NewSequential(s *schema.Schema, maxDepth int) *Sequential {
 	}
 }
 
+// WithRelay toggles Relay connection traversal: fields typed as a Relay
+// connection become a transparent edges.node hop instead of a dead end.
+func (s *Sequential) WithRelay(relay bool) *Sequential {
+	s.relay = relay
+	return s
+}
+
+// WithDirectiveFilter prunes fields before the traverser ever descends into
+// them: skipDeprecated drops @deprecated fields, requireDirective keeps only
+// fields carrying that directive, excludeDirective drops fields carrying it.
+// Pass "" to leave require/exclude unset.
+func (s *Sequential) WithDirectiveFilter(skipDeprecated bool, requireDirective, excludeDirective string) *Sequential {
+	s.filter = directiveFilter{
+		skipDeprecated:   skipDeprecated,
+		requireDirective: requireDirective,
+		excludeDirective: excludeDirective,
+	}
+	return s
+}
+
+// WithComplexityBudget bounds traversal by accumulated field cost rather
+// than depth alone: a branch is abandoned once following it would push the
+// path's score past maxComplexity. maxComplexity <= 0 disables the budget,
+// leaving maxDepth as the only bound. costs supplies per-field overrides;
+// pass complexity.Config{} to use cost-1-per-field defaults.
+func (s *Sequential) WithComplexityBudget(maxComplexity int, costs complexity.Config) *Sequential {
+	s.maxComplexity = maxComplexity
+	s.costs = costs
+	return s
+}
+
 func (s *Sequential) FindPaths(entryPoints []schema.EntryPoint, targetType string) []schema.GraphQLPath {
 	var paths []schema.GraphQLPath
-	
+
 	for _, ep := range entryPoints {
 		visited := make(map[string]bool)
 		visited[ep.Type] = true
-		
+
 		path := []schema.PathSegment{{Name: ep.Name, Type: ep.Type, Args: ep.Args}}
-		
+		score := 1
+
 		if ep.Type == targetType {
 			paths = append(paths, schema.GraphQLPath{
-				Segments: path,
-				Depth:    1,
+				Segments:   path,
+				Depth:      1,
+				Complexity: score,
 			})
 		}
-		
-		s.dfs(ep.Type, targetType, path, visited, 1, &paths)
+
+		s.dfs(ep.Type, targetType, path, visited, 1, score, &paths)
 	}
-	
+
 	return paths
 }
 
-func (s *Sequential) dfs(currentType, targetType string, currentPath []schema.PathSegment, 
-	visited map[string]bool, depth int, paths *[]schema.GraphQLPath) {
-	
+func (s *Sequential) dfs(currentType, targetType string, currentPath []schema.PathSegment,
+	visited map[string]bool, depth, score int, paths *[]schema.GraphQLPath) {
+
 	if depth >= s.maxDepth {
 		return
 	}
-	
+
 	typ := s.schema.GetType(currentType)
 	if typ == nil {
 		return
 	}
-	
+
 	for _, field := range typ.Fields {
 		if visited[field.Type] {
 			continue
 		}
-		
+		if !s.filter.allows(field) {
+			continue
+		}
+
+		newScore := score + s.costs.FieldCost(currentType, field)
+		if s.maxComplexity > 0 && newScore > s.maxComplexity {
+			continue
+		}
+
+		if s.relay && s.schema.IsConnectionType(field.Type) {
+			s.followConnection(field, targetType, currentPath, visited, depth, newScore, paths)
+			continue
+		}
+
+		fieldTyp := s.schema.GetType(field.Type)
+		if fieldTyp != nil && fieldTyp.IsAbstract() {
+			s.branchAbstract(fieldTyp, field, targetType, currentPath, visited, depth, newScore, paths)
+			continue
+		}
+
 		newSegment := schema.PathSegment{
 			Name: field.Name,
 			Type: field.Type,
 			Args: field.Args,
 		}
-		
+
 		newPath := append(currentPath, newSegment)
-		
+
 		if field.Type == targetType {
 			*paths = append(*paths, schema.GraphQLPath{
-				Segments: newPath,
-				Depth:    depth + 1,
+				Segments:   newPath,
+				Depth:      depth + 1,
+				Complexity: newScore,
 			})
 			continue
 		}
-		
+
 		newVisited := make(map[string]bool)
 		for k, v := range visited {
 			newVisited[k] = v
 		}
 		newVisited[field.Type] = true
-		
-		s.dfs(field.Type, targetType, newPath, newVisited, depth+1, paths)
+
+		s.dfs(field.Type, targetType, newPath, newVisited, depth+1, newScore, paths)
+	}
+}
+
+// followConnection treats a Relay connection field as a transparent hop
+// into its edges.node type, synthesizing first/after pagination variables
+// and recording the segment as Relay so the generator renders the
+// edges { cursor node { ... } } / pageInfo selection instead of naked fields.
+func (s *Sequential) followConnection(field schema.Field, targetType string,
+	currentPath []schema.PathSegment, visited map[string]bool, depth, score int, paths *[]schema.GraphQLPath) {
+
+	nodeType := s.schema.ConnectionNodeType(field.Type)
+	if nodeType == "" || visited[nodeType] {
+		return
+	}
+
+	newSegment := schema.PathSegment{
+		Name:  field.Name,
+		Type:  nodeType,
+		Args:  withPaginationArgs(field.Args),
+		Relay: true,
+	}
+	newPath := append(append([]schema.PathSegment{}, currentPath...), newSegment)
+
+	if nodeType == targetType {
+		*paths = append(*paths, schema.GraphQLPath{Segments: newPath, Depth: depth + 1, Complexity: score})
+		return
+	}
+
+	newVisited := make(map[string]bool)
+	for k, v := range visited {
+		newVisited[k] = v
+	}
+	newVisited[nodeType] = true
+
+	s.dfs(nodeType, targetType, newPath, newVisited, depth+1, score, paths)
+}
+
+// withPaginationArgs ensures first/after are present among a connection
+// field's arguments even when the schema doesn't declare them explicitly.
+func withPaginationArgs(args []schema.Arg) []schema.Arg {
+	has := map[string]bool{}
+	for _, a := range args {
+		has[a.Name] = true
+	}
+	result := append([]schema.Arg{}, args...)
+	if !has["first"] {
+		result = append(result, schema.Arg{Name: "first", Type: "Int", Required: true})
+	}
+	if !has["after"] {
+		result = append(result, schema.Arg{Name: "after", Type: "String", Required: true})
+	}
+	return result
+}
+
+// branchAbstract explores each concrete type a field's interface/union can
+// resolve to, recording the chosen concrete type in PathSegment.Fragment so
+// the generator can emit the matching inline fragment.
+func (s *Sequential) branchAbstract(abstractTyp *schema.Type, field schema.Field, targetType string,
+	currentPath []schema.PathSegment, visited map[string]bool, depth, score int, paths *[]schema.GraphQLPath) {
+
+	for _, concrete := range abstractTyp.PossibleTypes {
+		if visited[concrete] {
+			continue
+		}
+
+		newSegment := schema.PathSegment{
+			Name:     field.Name,
+			Type:     concrete,
+			Args:     field.Args,
+			Fragment: concrete,
+		}
+		newPath := append(append([]schema.PathSegment{}, currentPath...), newSegment)
+
+		if concrete == targetType {
+			*paths = append(*paths, schema.GraphQLPath{
+				Segments:   newPath,
+				Depth:      depth + 1,
+				Complexity: score,
+			})
+			continue
+		}
+
+		newVisited := make(map[string]bool)
+		for k, v := range visited {
+			newVisited[k] = v
+		}
+		newVisited[concrete] = true
+
+		s.dfs(concrete, targetType, newPath, newVisited, depth+1, score, paths)
 	}
 }
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// fullIntrospectionQuery is the standard GraphQL introspection document,
+// matching the shape IntrospectionFormat expects (queryType/mutationType/
+// subscriptionType + types, each with fields/args/interfaces/possibleTypes).
+const fullIntrospectionQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types {
+      ...FullType
+    }
+    directives {
+      name
+      description
+      args { ...InputValue }
+    }
+  }
+}
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// introspectOptions configures fetchIntrospection.
+type introspectOptions struct {
+	URL                string
+	Method             string // "POST" or "GET"
+	Headers            map[string]string
+	InsecureSkipVerify bool
+	CACertFile         string
+	CacheFile          string
+}
+
+// fetchIntrospection runs the standard introspection query against a live
+// endpoint and returns the raw response body, ready to hand to parseSchema.
+// When opts.CacheFile is set, a prior run's response is reused instead of
+// hitting the network, and a fresh fetch is written there for next time.
+func fetchIntrospection(opts introspectOptions) ([]byte, error) {
+	if opts.CacheFile != "" {
+		if cached, err := os.ReadFile(opts.CacheFile); err == nil {
+			return cached, nil
+		}
+	}
+
+	client, err := buildHTTPClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := buildIntrospectionRequest(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("introspection request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read introspection response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("introspection request failed: %s", resp.Status)
+	}
+
+	if opts.CacheFile != "" {
+		if err := os.WriteFile(opts.CacheFile, body, 0644); err != nil {
+			return nil, fmt.Errorf("write introspection cache: %w", err)
+		}
+	}
+
+	return body, nil
+}
+
+func buildHTTPClient(opts introspectOptions) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: opts.InsecureSkipVerify}
+
+	if opts.CACertFile != "" {
+		pem, err := os.ReadFile(opts.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA cert: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates found in %s", opts.CACertFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}, nil
+}
+
+func buildIntrospectionRequest(opts introspectOptions) (*http.Request, error) {
+	method := strings.ToUpper(opts.Method)
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	var req *http.Request
+	var err error
+
+	switch method {
+	case http.MethodGet:
+		q := url.Values{"query": {fullIntrospectionQuery}}
+		req, err = http.NewRequest(http.MethodGet, opts.URL+"?"+q.Encode(), nil)
+	case http.MethodPost:
+		body, marshalErr := json.Marshal(map[string]string{"query": fullIntrospectionQuery})
+		if marshalErr != nil {
+			return nil, fmt.Errorf("encode introspection request: %w", marshalErr)
+		}
+		req, err = http.NewRequest(http.MethodPost, opts.URL, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported -method %q (expected POST or GET)", opts.Method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("build introspection request: %w", err)
+	}
+
+	for k, v := range opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	return req, nil
+}
+
+// headerList collects repeated -header "Name: Value" flags.
+type headerList []string
+
+func (h *headerList) String() string {
+	return strings.Join(*h, ", ")
+}
+
+func (h *headerList) Set(value string) error {
+	*h = append(*h, value)
+	return nil
+}
+
+func (h *headerList) toMap() map[string]string {
+	headers := make(map[string]string, len(*h))
+	for _, raw := range *h {
+		parts := strings.SplitN(raw, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers
+}
@@ -5,6 +5,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/fatih/color"
@@ -45,10 +47,12 @@ type IntrospectionType struct {
 }
 
 type IntrospectionField struct {
-	Name        string             `json:"name"`
-	Description string             `json:"description"`
-	Type        TypeRef            `json:"type"`
-	Args        []IntrospectionArg `json:"args"`
+	Name              string             `json:"name"`
+	Description       string             `json:"description"`
+	Type              TypeRef            `json:"type"`
+	Args              []IntrospectionArg `json:"args"`
+	IsDeprecated      bool               `json:"isDeprecated,omitempty"`
+	DeprecationReason string             `json:"deprecationReason,omitempty"`
 }
 
 type IntrospectionArg struct {
@@ -75,15 +79,17 @@ type GitHubFormat struct {
 }
 
 type GitHubFieldDef struct {
-	Name         string            `json:"name"`
-	Type         string            `json:"type"`
-	Kind         string            `json:"kind"`
-	ID           string            `json:"id"`
-	Href         string            `json:"href"`
-	Description  string            `json:"description"`
-	Args         []GitHubArg       `json:"args"`         // Top-level queries/mutations use "args"
-	InputFields  []GitHubFieldDef  `json:"inputFields"`  // Mutations use inputFields
-	ReturnFields []GitHubReturnField `json:"returnFields"` // Mutations have returnFields
+	Name              string              `json:"name"`
+	Type              string              `json:"type"`
+	Kind              string              `json:"kind"`
+	ID                string              `json:"id"`
+	Href              string              `json:"href"`
+	Description       string              `json:"description"`
+	Args              []GitHubArg         `json:"args"`         // Top-level queries/mutations use "args"
+	InputFields       []GitHubFieldDef    `json:"inputFields"`  // Mutations use inputFields
+	ReturnFields      []GitHubReturnField `json:"returnFields"` // Mutations have returnFields
+	IsDeprecated      bool                `json:"isDeprecated,omitempty"`
+	DeprecationReason string              `json:"deprecationReason,omitempty"`
 }
 
 type GitHubArg struct {
@@ -174,49 +180,116 @@ type Node struct {
 }
 
 type Edge struct {
-	Name      string
-	Target    string
-	Arguments []Arg
+	Name              string
+	Target            string
+	Arguments         []Arg
+	IsDeprecated      bool
+	DeprecationReason string
+	Directives        []Directive
 }
 
 type Arg struct {
+	Name         string
+	Type         string
+	DefaultValue string
+}
+
+// Directive is a generic name/args capture of a field directive (e.g.
+// @deprecated(reason: "...")), used alongside the IsDeprecated/
+// DeprecationReason shortcuts on Edge.
+type Directive struct {
 	Name string
-	Type string
+	Args map[string]string
 }
 
 func main() {
 	var (
-		schemaFile       = flag.String("schema", "", "Path to schema JSON (introspection or GitHub format)")
+		schemaFile       = flag.String("schema", "", "Path to schema JSON, SDL file/directory (introspection, GitHub, or SDL format)")
+		url              = flag.String("url", "", "Live GraphQL endpoint to introspect instead of -schema")
+		method           = flag.String("method", "POST", "HTTP method to use for -url (POST or GET)")
+		insecure         = flag.Bool("insecure", false, "Skip TLS certificate verification for -url")
+		caCertFile       = flag.String("cacert", "", "Path to a CA certificate bundle to trust for -url")
+		cacheFile        = flag.String("cache", "", "Path to cache the -url introspection response (reused on later runs)")
 		targetType       = flag.String("type", "", "Target type to find paths to (case-sensitive)")
 		maxDepth         = flag.Int("max-depth", 15, "Maximum search depth")
+		maxRevisits      = flag.Int("max-revisits", 1, "How many extra times a single edge may be revisited on one path")
+		maxPaths         = flag.Int("max-paths", 0, "Cap on total paths returned, ranked shortest-first (0 = unlimited)")
 		includeMutations = flag.Bool("mutations", false, "Include mutation paths as entry points")
 		verbose          = flag.Bool("v", false, "Verbose output")
 		noColor          = flag.Bool("no-color", false, "Disable colored output")
+		emit             = flag.String("emit", "", `Emit mode: "queries" renders each path as an executable GraphQL document`)
+		skipDeprecated   = flag.Bool("skip-deprecated", false, "Prune @deprecated fields from the traversal")
+		expandAbstract   = flag.String("expand-abstract", "implementing", `How to expand interfaces/unions: "none", "implementing", or "matching-only" (only types that can reach -type)`)
+		abstractMaxFanout = flag.Int("abstract-max-fanout", 0, "Cap on possible types explored per interface/union (0 = unlimited)")
+		headers           headerList
+		requireDirectives stringList
+		excludeDirectives stringList
 	)
+	flag.Var(&headers, "header", `Auth header as "Name: Value" (repeatable)`)
+	flag.Var(&requireDirectives, "require-directive", "Only traverse fields carrying this directive (repeatable)")
+	flag.Var(&excludeDirectives, "exclude-directive", "Prune fields carrying this directive (repeatable)")
 	flag.Parse()
 
 	if *noColor {
 		color.NoColor = true
 	}
 
-	if *schemaFile == "" || *targetType == "" {
+	if (*schemaFile == "" && *url == "") || (*schemaFile != "" && *url != "") || *targetType == "" {
 		printUsage()
 		os.Exit(1)
 	}
 
-	// Read schema
-	data, err := os.ReadFile(*schemaFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	switch *expandAbstract {
+	case "none", "implementing", "matching-only":
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported -expand-abstract %q (expected \"none\", \"implementing\", or \"matching-only\")\n", *expandAbstract)
 		os.Exit(1)
 	}
 
-	if *verbose {
-		fmt.Printf("Loading schema from %s...\n", color.CyanString(*schemaFile))
+	var graph *Graph
+	var format string
+	var err error
+
+	switch {
+	case *url != "":
+		if *verbose {
+			fmt.Printf("Introspecting %s...\n", color.CyanString(*url))
+		}
+		data, fetchErr := fetchIntrospection(introspectOptions{
+			URL:                *url,
+			Method:             *method,
+			Headers:            headers.toMap(),
+			InsecureSkipVerify: *insecure,
+			CACertFile:         *caCertFile,
+			CacheFile:          *cacheFile,
+		})
+		if fetchErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", fetchErr)
+			os.Exit(1)
+		}
+		graph, format, err = parseSchema(data, "", *includeMutations)
+
+	default:
+		info, statErr := os.Stat(*schemaFile)
+		if statErr == nil && info.IsDir() {
+			if *verbose {
+				fmt.Printf("Loading multi-file SDL schema from %s...\n", color.CyanString(*schemaFile))
+			}
+			graph, err = loadSDLPath(*schemaFile, *includeMutations)
+			format = "GraphQL SDL (multi-file)"
+		} else {
+			if *verbose {
+				fmt.Printf("Loading schema from %s...\n", color.CyanString(*schemaFile))
+			}
+			data, readErr := os.ReadFile(*schemaFile)
+			if readErr != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", readErr)
+				os.Exit(1)
+			}
+			graph, format, err = parseSchema(data, *schemaFile, *includeMutations)
+		}
 	}
 
-	// Parse schema (auto-detect format)
-	graph, format, err := parseSchema(data, *includeMutations)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Parse Error: %v\n", err)
 		os.Exit(1)
@@ -238,8 +311,19 @@ func main() {
 		os.Exit(1)
 	}
 
+	filter := edgeFilter{
+		skipDeprecated:    *skipDeprecated,
+		requireDirectives: requireDirectives,
+		excludeDirectives: excludeDirectives,
+	}
+	limits := traversalLimits{
+		maxRevisits: *maxRevisits,
+		maxPaths:    *maxPaths,
+	}
+	expand := newAbstractExpansion(*expandAbstract, *abstractMaxFanout)
+
 	// Find paths
-	paths := findPaths(graph, *targetType, *maxDepth)
+	paths := findPaths(graph, *targetType, *maxDepth, filter, limits, expand)
 
 	typeNode := graph.Nodes[*targetType]
 	fmt.Printf("Target: %s (%s)\n", color.CyanString(*targetType), color.YellowString(typeNode.Kind))
@@ -249,6 +333,14 @@ func main() {
 	if len(paths) == 0 {
 		fmt.Printf("Warning: No paths found to %s within depth limit (%d)\n", *targetType, *maxDepth)
 		os.Exit(2)
+	} else if *emit == "queries" {
+		queryPaths := findQueryPaths(graph, *targetType, *maxDepth, filter, limits, expand)
+		queries := emitQueries(graph, queryPaths)
+		fmt.Printf("Found %s paths, emitting GraphQL documents:\n\n", color.GreenString(fmt.Sprintf("%d", len(queries))))
+		printGeneratedQueries(queries)
+	} else if *emit != "" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported -emit mode %q (expected \"queries\")\n", *emit)
+		os.Exit(1)
 	} else {
 		fmt.Printf("Found %s paths:\n\n", color.GreenString(fmt.Sprintf("%d", len(paths))))
 		for i, path := range paths {
@@ -261,16 +353,34 @@ func printUsage() {
 	fmt.Println("GraphQL Path Enumeration Tool (Go Edition)")
 	fmt.Println()
 	fmt.Println("Enumerates all GraphQL paths from root queries/mutations to a target type.")
-	fmt.Println("Supports standard introspection JSON and GitHub's custom schema format.")
+	fmt.Println("Supports standard introspection JSON, GitHub's custom schema format, and SDL.")
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Println("  graphql-enum -schema <file.json> -type <TypeName> [options]")
+	fmt.Println("  graphql-enum -schema <schema.graphql|schema-dir> -type <TypeName> [options]")
+	fmt.Println("  graphql-enum -url <endpoint> -type <TypeName> [options]")
 	fmt.Println()
 	fmt.Println("Options:")
-	fmt.Println("  -schema string     Path to schema JSON file (required)")
+	fmt.Println("  -schema string     Path to schema JSON file, a single .graphql/.graphqls file, or")
+	fmt.Println("                     a directory of .graphql/.graphqls files to merge (required unless -url is set)")
+	fmt.Println("  -url string        Live GraphQL endpoint to introspect instead of -schema")
+	fmt.Println("  -header value      Auth header as \"Name: Value\" for -url (repeatable)")
+	fmt.Println("  -method string     HTTP method for -url: POST or GET (default: POST)")
+	fmt.Println("  -insecure          Skip TLS certificate verification for -url")
+	fmt.Println("  -cacert string     CA certificate bundle to trust for -url")
+	fmt.Println("  -cache string      Cache the -url introspection response at this path")
+	fmt.Println("  -emit string       \"queries\" renders each path as an executable GraphQL document + variables JSON")
 	fmt.Println("  -type string       Target type name to find paths to (required)")
 	fmt.Println("  -max-depth int     Maximum traversal depth (default: 15)")
+	fmt.Println("  -max-revisits int  How many extra times a single edge may be revisited on one path (default: 1)")
+	fmt.Println("  -max-paths int     Cap on total paths returned, ranked shortest-first (default: unlimited)")
 	fmt.Println("  -mutations         Include Mutation fields as entry points")
+	fmt.Println("  -skip-deprecated   Prune @deprecated fields from the traversal")
+	fmt.Println("  -require-directive value  Only traverse fields carrying this directive (repeatable)")
+	fmt.Println("  -exclude-directive value  Prune fields carrying this directive (repeatable)")
+	fmt.Println("  -expand-abstract string   How to expand interfaces/unions: \"none\", \"implementing\" (default), or")
+	fmt.Println("                            \"matching-only\" (only possible types that can reach -type)")
+	fmt.Println("  -abstract-max-fanout int  Cap on possible types explored per interface/union (default: unlimited)")
 	fmt.Println("  -v                 Verbose output")
 	fmt.Println("  -no-color          Disable colored output")
 	fmt.Println()
@@ -278,6 +388,12 @@ func printUsage() {
 	fmt.Println("  graphql-enum -schema schema.json -type User")
 	fmt.Println("  graphql-enum -schema github-schema.json -type Repository -mutations")
 	fmt.Println("  graphql-enum -schema schema.json -type Issue -max-depth 20 -v")
+	fmt.Println("  graphql-enum -url https://api.example.com/graphql -header \"Authorization: Bearer tok\" -type User")
+	fmt.Println("  graphql-enum -schema schema.json -type User -emit queries | tee queries.txt")
+	fmt.Println("  graphql-enum -schema schemas/ -type Repository -v")
+	fmt.Println("  graphql-enum -schema schema.json -type User -skip-deprecated -exclude-directive auth")
+	fmt.Println("  graphql-enum -schema github-schema.json -type Repository -max-revisits 2 -max-paths 20")
+	fmt.Println("  graphql-enum -schema github-schema.json -type Repository -expand-abstract matching-only -abstract-max-fanout 3")
 }
 
 func findSimilarTypes(graph *Graph, target string) []string {
@@ -294,7 +410,20 @@ func findSimilarTypes(graph *Graph, target string) []string {
 	return suggestions
 }
 
-func parseSchema(data []byte, includeMutations bool) (*Graph, string, error) {
+// parseSchema auto-detects the schema format from its content (or, when
+// filename is non-empty, its .graphql/.graphqls extension) and builds a
+// Graph from it. filename may be empty, e.g. for -url introspection results
+// which are never SDL.
+func parseSchema(data []byte, filename string, includeMutations bool) (*Graph, string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == ".graphql" || ext == ".graphqls" {
+		graph, err := parseSDL(data, includeMutations)
+		if err != nil {
+			return nil, "", err
+		}
+		return graph, "GraphQL SDL", nil
+	}
+
 	// Try introspection format
 	var intro IntrospectionFormat
 	if err := json.Unmarshal(data, &intro); err == nil && intro.Data.Schema.Types != nil {
@@ -311,7 +440,16 @@ func parseSchema(data []byte, includeMutations bool) (*Graph, string, error) {
 		}
 	}
 
-	return nil, "", fmt.Errorf("unknown schema format (expected introspection or GitHub format)")
+	// Fall back to SDL when the content isn't valid JSON at all.
+	if !json.Valid(data) {
+		graph, err := parseSDL(data, includeMutations)
+		if err != nil {
+			return nil, "", fmt.Errorf("unknown schema format (tried introspection, GitHub, and SDL): %w", err)
+		}
+		return graph, "GraphQL SDL", nil
+	}
+
+	return nil, "", fmt.Errorf("unknown schema format (expected introspection, GitHub, or SDL format)")
 }
 
 func buildFromIntrospection(schema *IntrospectionFormat, includeMutations bool) *Graph {
@@ -341,8 +479,16 @@ func buildFromIntrospection(schema *IntrospectionFormat, includeMutations bool)
 			}
 
 			edge := Edge{
-				Name:   f.Name,
-				Target: targetType,
+				Name:              f.Name,
+				Target:            targetType,
+				IsDeprecated:      f.IsDeprecated,
+				DeprecationReason: f.DeprecationReason,
+			}
+			if f.IsDeprecated {
+				edge.Directives = append(edge.Directives, Directive{
+					Name: "deprecated",
+					Args: map[string]string{"reason": f.DeprecationReason},
+				})
 			}
 
 			for _, a := range f.Args {
@@ -460,9 +606,17 @@ func convertGitHubObject(obj GitHubTypeDef) *Node {
 
 	for _, f := range obj.Fields {
 		edge := Edge{
-			Name:   f.Name,
-			Target: cleanTypeName(f.Type),
-			Arguments: []Arg{},
+			Name:              f.Name,
+			Target:            cleanTypeName(f.Type),
+			Arguments:         []Arg{},
+			IsDeprecated:      f.IsDeprecated,
+			DeprecationReason: f.DeprecationReason,
+		}
+		if f.IsDeprecated {
+			edge.Directives = append(edge.Directives, Directive{
+				Name: "deprecated",
+				Args: map[string]string{"reason": f.DeprecationReason},
+			})
 		}
 		for _, a := range f.Arguments {
 			edge.Arguments = append(edge.Arguments, Arg{
@@ -489,9 +643,17 @@ func convertGitHubInterface(iface GitHubTypeDef) *Node {
 
 	for _, f := range iface.Fields {
 		edge := Edge{
-			Name:      f.Name,
-			Target:    cleanTypeName(f.Type),
-			Arguments: []Arg{},
+			Name:              f.Name,
+			Target:            cleanTypeName(f.Type),
+			Arguments:         []Arg{},
+			IsDeprecated:      f.IsDeprecated,
+			DeprecationReason: f.DeprecationReason,
+		}
+		if f.IsDeprecated {
+			edge.Directives = append(edge.Directives, Directive{
+				Name: "deprecated",
+				Args: map[string]string{"reason": f.DeprecationReason},
+			})
 		}
 		for _, a := range f.Arguments {
 			edge.Arguments = append(edge.Arguments, Arg{
@@ -508,9 +670,17 @@ func convertGitHubInterface(iface GitHubTypeDef) *Node {
 func convertGitHubTopLevelField(f GitHubFieldDef) Edge {
 	target := cleanTypeName(f.Type)
 	edge := Edge{
-		Name:      f.Name,
-		Target:    target,
-		Arguments: []Arg{},
+		Name:              f.Name,
+		Target:            target,
+		Arguments:         []Arg{},
+		IsDeprecated:      f.IsDeprecated,
+		DeprecationReason: f.DeprecationReason,
+	}
+	if f.IsDeprecated {
+		edge.Directives = append(edge.Directives, Directive{
+			Name: "deprecated",
+			Args: map[string]string{"reason": f.DeprecationReason},
+		})
 	}
 	for _, a := range f.Args {
 		edge.Arguments = append(edge.Arguments, Arg{
@@ -540,9 +710,17 @@ func convertGitHubMutation(m GitHubFieldDef) Edge {
 	}
 
 	edge := Edge{
-		Name:      m.Name,
-		Target:    targetType,
-		Arguments: []Arg{},
+		Name:              m.Name,
+		Target:            targetType,
+		Arguments:         []Arg{},
+		IsDeprecated:      m.IsDeprecated,
+		DeprecationReason: m.DeprecationReason,
+	}
+	if m.IsDeprecated {
+		edge.Directives = append(edge.Directives, Directive{
+			Name: "deprecated",
+			Args: map[string]string{"reason": m.DeprecationReason},
+		})
 	}
 
 	// Use inputFields as arguments for mutations
@@ -591,41 +769,187 @@ func isScalarType(kind string) bool {
 	return kind == "scalars" || kind == "SCALAR" || kind == "enums" || kind == "ENUM"
 }
 
-func findPaths(graph *Graph, target string, maxDepth int) [][]string {
+// stringList collects a repeatable flag's values, e.g. -require-directive.
+type stringList []string
+
+func (s *stringList) String() string {
+	return strings.Join(*s, ", ")
+}
+
+func (s *stringList) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// edgeFilter controls which edges dfs/qdfs may traverse, based on
+// @deprecated status and directive membership (-skip-deprecated,
+// -require-directive, -exclude-directive).
+type edgeFilter struct {
+	skipDeprecated    bool
+	requireDirectives []string
+	excludeDirectives []string
+}
+
+func (f edgeFilter) allows(e Edge) bool {
+	if f.skipDeprecated && e.IsDeprecated {
+		return false
+	}
+	for _, name := range f.excludeDirectives {
+		if hasDirective(e, name) {
+			return false
+		}
+	}
+	for _, name := range f.requireDirectives {
+		if !hasDirective(e, name) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasDirective(e Edge, name string) bool {
+	for _, d := range e.Directives {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// traversalLimits bounds how aggressively dfs/qdfs revisit edges and how
+// many total paths a search is allowed to surface.
+type traversalLimits struct {
+	maxRevisits int // how many extra times a (parent-type, field-name) edge may appear on one path
+	maxPaths    int // 0 = unlimited; ranked down to this many shortest paths when exceeded
+}
+
+// abstractExpansion controls how dfs/qdfs walk a Node's PossibleTypes
+// (-expand-abstract) and how many of them it will follow (-abstract-max-fanout).
+//
+//   - "none": never expand into possible types; interfaces/unions are leaves
+//     unless they are the target themselves.
+//   - "implementing": expand into every possible type (the historical
+//     behavior).
+//   - "matching-only": expand only into possible types that can actually
+//     reach target, avoiding combinatorial blowup on wide interfaces like
+//     GitHub's Node.
+type abstractExpansion struct {
+	mode       string
+	maxFanout  int
+	reachCache map[string]bool
+}
+
+func newAbstractExpansion(mode string, maxFanout int) *abstractExpansion {
+	return &abstractExpansion{mode: mode, maxFanout: maxFanout, reachCache: make(map[string]bool)}
+}
+
+// candidates returns the possible types of node that dfs/qdfs should descend
+// into for the given target, honoring mode and maxFanout.
+func (a *abstractExpansion) candidates(graph *Graph, node *Node, target string) []string {
+	var types []string
+	switch a.mode {
+	case "none":
+		return nil
+	case "matching-only":
+		for _, pt := range node.PossibleTypes {
+			if pt == target || a.canReach(graph, pt, target) {
+				types = append(types, pt)
+			}
+		}
+	default: // "implementing"
+		types = node.PossibleTypes
+	}
+
+	if a.maxFanout > 0 && len(types) > a.maxFanout {
+		types = types[:a.maxFanout]
+	}
+	return types
+}
+
+func (a *abstractExpansion) canReach(graph *Graph, from, target string) bool {
+	if v, ok := a.reachCache[from]; ok {
+		return v
+	}
+	result := typeCanReach(graph, from, target, 20)
+	a.reachCache[from] = result
+	return result
+}
+
+// typeCanReach reports whether target is reachable from "from" by following
+// fields and possible types, used by "matching-only" to restrict abstract
+// expansion to concrete types that are actually worth visiting.
+func typeCanReach(graph *Graph, from, target string, depth int) bool {
+	if from == target {
+		return true
+	}
+	return canReachDFS(graph, from, target, depth, make(map[string]bool))
+}
+
+func canReachDFS(graph *Graph, current, target string, depth int, visited map[string]bool) bool {
+	if depth < 0 || visited[current] {
+		return false
+	}
+	visited[current] = true
+
+	node, exists := graph.Nodes[current]
+	if !exists {
+		return false
+	}
+
+	for _, f := range node.Fields {
+		if f.Target == target || canReachDFS(graph, f.Target, target, depth-1, visited) {
+			return true
+		}
+	}
+	for _, pt := range node.PossibleTypes {
+		if pt == target || canReachDFS(graph, pt, target, depth-1, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// edgeKey identifies a (parent-type, field-name) edge for revisit budgeting,
+// tracked per-edge rather than a single global/per-type visited set so that
+// distinct routes through the same intermediate type (e.g. Repository
+// reached via many different parents) are still discovered.
+func edgeKey(parentType, fieldName string) string {
+	return parentType + "." + fieldName
+}
+
+func findPaths(graph *Graph, target string, maxDepth int, filter edgeFilter, limits traversalLimits, expand *abstractExpansion) [][]string {
 	var results [][]string
 
 	for _, root := range graph.Roots {
-		visited := make(map[string]bool)
+		edgeVisits := make(map[string]int)
 		var current []string
-		dfs(graph, root, target, current, visited, &results, 0, maxDepth)
+		dfs(graph, root, target, current, edgeVisits, &results, 0, maxDepth, filter, limits, expand)
+	}
+
+	if limits.maxPaths > 0 && len(results) > limits.maxPaths {
+		results = rankShortestPaths(results, limits.maxPaths)
 	}
 
 	return results
 }
 
-func dfs(graph *Graph, current, target string, path []string, visited map[string]bool, results *[][]string, depth, maxDepth int) {
+func dfs(graph *Graph, current, target string, path []string, edgeVisits map[string]int, results *[][]string, depth, maxDepth int, filter edgeFilter, limits traversalLimits, expand *abstractExpansion) {
 	if depth > maxDepth {
 		return
 	}
 
-	if visited[current] {
-		return
-	}
-
 	node, exists := graph.Nodes[current]
 	if !exists {
 		return
 	}
 
 	newPath := append(path, current)
-	visited[current] = true
 
 	// Found target (but path must be longer than just the root itself)
 	if current == target && len(newPath) > 1 {
 		pathCopy := make([]string, len(newPath))
 		copy(pathCopy, newPath)
 		*results = append(*results, pathCopy)
-		visited[current] = false
 		return
 	}
 
@@ -638,11 +962,23 @@ func dfs(graph *Graph, current, target string, path []string, visited map[string
 			continue
 		}
 
+		if !filter.allows(field) {
+			continue
+		}
+
+		key := edgeKey(current, field.Name)
+		if edgeVisits[key] > limits.maxRevisits {
+			continue
+		}
+
 		fieldStep := field.Name
 		if len(field.Arguments) > 0 {
 			args := formatArguments(field.Arguments)
 			fieldStep = fmt.Sprintf("%s(%s)", field.Name, args)
 		}
+		if field.IsDeprecated {
+			fieldStep += " [deprecated]"
+		}
 
 		pathWithField := append(newPath, fieldStep)
 
@@ -651,22 +987,41 @@ func dfs(graph *Graph, current, target string, path []string, visited map[string
 			copy(pathCopy, pathWithField)
 			*results = append(*results, pathCopy)
 		} else {
-			dfs(graph, fieldType, target, pathWithField, visited, results, depth+1, maxDepth)
+			edgeVisits[key]++
+			dfs(graph, fieldType, target, pathWithField, edgeVisits, results, depth+1, maxDepth, filter, limits, expand)
+			edgeVisits[key]--
 		}
 	}
 
-	// Explore possible types (interfaces/unions)
-	for _, subType := range node.PossibleTypes {
+	// Explore possible types (interfaces/unions), pruned by expand's mode.
+	for _, subType := range expand.candidates(graph, node, target) {
 		if subType == target {
 			pathCopy := make([]string, len(newPath))
 			copy(pathCopy, newPath)
 			*results = append(*results, append(pathCopy, subType))
 		} else {
-			dfs(graph, subType, target, newPath, visited, results, depth+1, maxDepth)
+			key := edgeKey(current, subType)
+			if edgeVisits[key] > limits.maxRevisits {
+				continue
+			}
+			edgeVisits[key]++
+			dfs(graph, subType, target, newPath, edgeVisits, results, depth+1, maxDepth, filter, limits, expand)
+			edgeVisits[key]--
 		}
 	}
+}
 
-	visited[current] = false
+// rankShortestPaths keeps the k shortest of paths, in the spirit of Yen's
+// k-shortest-paths algorithm. dfs already enumerates every route within
+// maxDepth/maxRevisits, so ranking here is just a stable sort by hop
+// count rather than Yen's incremental search over a weighted graph.
+func rankShortestPaths(paths [][]string, k int) [][]string {
+	ranked := make([][]string, len(paths))
+	copy(ranked, paths)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return len(ranked[i]) < len(ranked[j])
+	})
+	return ranked[:k]
 }
 
 var scalarTypes = map[string]bool{
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// QueryStep is one hop of a structured path used for query generation, in
+// contrast with the display-only string steps dfs/formatPath produce.
+// An interface/union expansion adds no step of its own; it is recorded in
+// the Fragment of the step whose field selected the abstract type.
+type QueryStep struct {
+	Field     string
+	Target    string
+	Arguments []Arg
+	Fragment  string // concrete type name (rendered as "... on Fragment") when expanded through PossibleTypes
+}
+
+// QueryPath is a single root-to-target route, tagged with the operation
+// ("query" or "mutation") its root type starts.
+type QueryPath struct {
+	Operation string
+	Steps     []QueryStep
+}
+
+// GeneratedQuery is a syntactically valid GraphQL operation document
+// rendered from one discovered path, paired with a variables skeleton ready
+// to hand to a client (or straight to curl).
+type GeneratedQuery struct {
+	Index     int                    `json:"index"`
+	Path      string                 `json:"path"`
+	Operation string                 `json:"operation"`
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// findQueryPaths mirrors findPaths/dfs but records the field/argument
+// structure each hop needs, rather than a flattened display string.
+func findQueryPaths(graph *Graph, target string, maxDepth int, filter edgeFilter, limits traversalLimits, expand *abstractExpansion) []QueryPath {
+	var results []QueryPath
+
+	for _, root := range graph.Roots {
+		operation := "query"
+		if strings.Contains(strings.ToLower(root), "mutation") {
+			operation = "mutation"
+		}
+		edgeVisits := make(map[string]int)
+		qdfs(graph, root, target, nil, edgeVisits, &results, operation, 0, maxDepth, filter, limits, expand)
+	}
+
+	if limits.maxPaths > 0 && len(results) > limits.maxPaths {
+		results = rankShortestQueryPaths(results, limits.maxPaths)
+	}
+
+	return results
+}
+
+func qdfs(graph *Graph, current, target string, path []QueryStep, edgeVisits map[string]int, results *[]QueryPath, operation string, depth, maxDepth int, filter edgeFilter, limits traversalLimits, expand *abstractExpansion) {
+	if depth > maxDepth {
+		return
+	}
+
+	node, exists := graph.Nodes[current]
+	if !exists {
+		return
+	}
+
+	for _, field := range node.Fields {
+		fieldType := field.Target
+		if isScalar(fieldType) && fieldType != target {
+			continue
+		}
+		if !filter.allows(field) {
+			continue
+		}
+
+		key := edgeKey(current, field.Name)
+		if edgeVisits[key] > limits.maxRevisits {
+			continue
+		}
+
+		step := QueryStep{Field: field.Name, Target: fieldType, Arguments: field.Arguments}
+		newPath := append(append([]QueryStep{}, path...), step)
+
+		if fieldType == target {
+			*results = append(*results, QueryPath{Operation: operation, Steps: newPath})
+		} else {
+			edgeVisits[key]++
+			qdfs(graph, fieldType, target, newPath, edgeVisits, results, operation, depth+1, maxDepth, filter, limits, expand)
+			edgeVisits[key]--
+		}
+	}
+
+	// Abstract types: expand into each possible concrete type (per expand's
+	// mode) as an inline fragment on the most recently selected field rather
+	// than a new field.
+	for _, subType := range expand.candidates(graph, node, target) {
+		annotated := append([]QueryStep{}, path...)
+		if len(annotated) > 0 {
+			annotated[len(annotated)-1].Fragment = subType
+		}
+		if subType == target {
+			*results = append(*results, QueryPath{Operation: operation, Steps: annotated})
+		} else {
+			key := edgeKey(current, subType)
+			if edgeVisits[key] > limits.maxRevisits {
+				continue
+			}
+			edgeVisits[key]++
+			qdfs(graph, subType, target, annotated, edgeVisits, results, operation, depth+1, maxDepth, filter, limits, expand)
+			edgeVisits[key]--
+		}
+	}
+}
+
+// rankShortestQueryPaths keeps the k shortest of paths (by hop count), the
+// same simplified Yen's-style ranking findPaths/rankShortestPaths applies.
+func rankShortestQueryPaths(paths []QueryPath, k int) []QueryPath {
+	ranked := make([]QueryPath, len(paths))
+	copy(ranked, paths)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return len(ranked[i].Steps) < len(ranked[j].Steps)
+	})
+	return ranked[:k]
+}
+
+// emitQueries renders each discovered path as an executable GraphQL
+// operation, materializing field arguments as $variables and adding a
+// minimal leaf selection (__typename plus a handful of scalar fields) on
+// the target type.
+func emitQueries(graph *Graph, paths []QueryPath) []GeneratedQuery {
+	queries := make([]GeneratedQuery, len(paths))
+	for i, p := range paths {
+		queries[i] = buildQuery(graph, p, i+1)
+	}
+	return queries
+}
+
+// stepVarName names a step's argument variable, including the step's index
+// in the path so a field revisited via -max-revisits (e.g. a cyclic path
+// that passes through the same field twice) doesn't collide with itself in
+// the operation's variable definitions.
+func stepVarName(stepIndex int, field, arg string) string {
+	return fmt.Sprintf("%s_%d_%s", field, stepIndex, arg)
+}
+
+func buildQuery(graph *Graph, qp QueryPath, index int) GeneratedQuery {
+	vars := make(map[string]interface{})
+	var varDefs []string
+
+	for i, step := range qp.Steps {
+		for _, arg := range step.Arguments {
+			varName := stepVarName(i, step.Field, arg.Name)
+			vars[varName] = exampleValueFor(arg.Type)
+			varDefs = append(varDefs, fmt.Sprintf("$%s: %s", varName, arg.Type))
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(qp.Operation)
+	if len(varDefs) > 0 {
+		b.WriteString("(" + strings.Join(varDefs, ", ") + ")")
+	}
+	b.WriteString(" {\n")
+
+	indent := "  "
+	closes := 0
+	for i, step := range qp.Steps {
+		b.WriteString(indent + step.Field)
+		if len(step.Arguments) > 0 {
+			var parts []string
+			for _, arg := range step.Arguments {
+				varName := stepVarName(i, step.Field, arg.Name)
+				parts = append(parts, fmt.Sprintf("%s: $%s", arg.Name, varName))
+			}
+			b.WriteString("(" + strings.Join(parts, ", ") + ")")
+		}
+		b.WriteString(" {\n")
+		indent += "  "
+		closes++
+
+		leafType := step.Target
+		if step.Fragment != "" && fragmentImplements(graph, step.Target, step.Fragment) {
+			b.WriteString(indent + "... on " + step.Fragment + " {\n")
+			indent += "  "
+			closes++
+			leafType = step.Fragment
+		}
+
+		if i == len(qp.Steps)-1 {
+			writeLeafSelection(&b, graph, indent, leafType)
+		}
+	}
+
+	for ; closes > 0; closes-- {
+		indent = indent[:len(indent)-2]
+		b.WriteString("\n" + indent + "}")
+	}
+	b.WriteString("\n}")
+
+	return GeneratedQuery{
+		Index:     index,
+		Path:      formatQueryPath(qp),
+		Operation: qp.Operation,
+		Query:     b.String(),
+		Variables: vars,
+	}
+}
+
+// fragmentImplements reports whether fragment is a concrete type actually
+// listed in interfaceType's PossibleTypes, guarding against emitting an
+// "... on Fragment" inline fragment for a type that doesn't implement the
+// interface/union it's being nested under.
+func fragmentImplements(graph *Graph, interfaceType, fragment string) bool {
+	node, exists := graph.Nodes[interfaceType]
+	if !exists {
+		return false
+	}
+	for _, pt := range node.PossibleTypes {
+		if pt == fragment {
+			return true
+		}
+	}
+	return false
+}
+
+// writeLeafSelection writes __typename plus a handful of the target type's
+// argument-less scalar fields, so the generated document is executable
+// without the caller having to hand-pick a selection set.
+func writeLeafSelection(b *strings.Builder, graph *Graph, indent, typeName string) {
+	b.WriteString(indent + "__typename")
+
+	node, exists := graph.Nodes[typeName]
+	if !exists {
+		return
+	}
+
+	count := 0
+	for _, f := range node.Fields {
+		if len(f.Arguments) == 0 && isScalar(f.Target) {
+			b.WriteString("\n" + indent + f.Name)
+			count++
+			if count >= 5 {
+				break
+			}
+		}
+	}
+}
+
+// exampleValueFor picks a placeholder value by scalar kind for the
+// companion variables skeleton.
+func exampleValueFor(typeName string) interface{} {
+	switch {
+	case strings.Contains(typeName, "Int"):
+		return 42
+	case strings.Contains(typeName, "Float"):
+		return 3.14
+	case strings.Contains(typeName, "Boolean"):
+		return true
+	case strings.Contains(typeName, "ID"):
+		return "123"
+	default:
+		return "example_string"
+	}
+}
+
+func formatQueryPath(qp QueryPath) string {
+	parts := []string{qp.Operation}
+	for _, step := range qp.Steps {
+		if step.Fragment != "" {
+			parts = append(parts, fmt.Sprintf("%s → ... on %s", step.Field, step.Fragment))
+		} else {
+			parts = append(parts, step.Field)
+		}
+	}
+	return strings.Join(parts, " → ")
+}
+
+// printGeneratedQueries writes each query document to stdout followed by its
+// companion JSON variables skeleton, ready to pipe into curl or a client.
+func printGeneratedQueries(queries []GeneratedQuery) {
+	for _, q := range queries {
+		fmt.Printf("# Path %d: %s\n%s\n\n", q.Index, q.Path, q.Query)
+		varsJSON, _ := json.MarshalIndent(q.Variables, "", "  ")
+		fmt.Printf("# Variables:\n%s\n\n", varsJSON)
+	}
+}
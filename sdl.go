@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// loadSDLPath reads a schema from path, which may be a single SDL
+// (.graphql/.graphqls) file or a directory containing several, and merges
+// them into a single Graph via gqlparser.
+func loadSDLPath(path string, includeMutations bool) (*Graph, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat schema path: %w", err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("read schema directory: %w", err)
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			ext := strings.ToLower(filepath.Ext(e.Name()))
+			if ext == ".graphql" || ext == ".graphqls" {
+				files = append(files, filepath.Join(path, e.Name()))
+			}
+		}
+		sort.Strings(files)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no .graphql/.graphqls files found in %s", path)
+		}
+	} else {
+		files = []string{path}
+	}
+
+	sources := make([]*ast.Source, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("read schema file %s: %w", f, err)
+		}
+		sources = append(sources, &ast.Source{Name: f, Input: string(data)})
+	}
+
+	return buildGraphFromSDL(sources, includeMutations)
+}
+
+// parseSDL parses a single in-memory SDL document, used by parseSchema's
+// auto-detected fallback path.
+func parseSDL(data []byte, includeMutations bool) (*Graph, error) {
+	return buildGraphFromSDL([]*ast.Source{{Name: "schema.graphql", Input: string(data)}}, includeMutations)
+}
+
+// buildGraphFromSDL translates a parsed gqlparser *ast.Schema into the same
+// Graph/Node/Edge structures the introspection and GitHub loaders build, so
+// the existing dfs traversal works unchanged regardless of input format.
+func buildGraphFromSDL(sources []*ast.Source, includeMutations bool) (*Graph, error) {
+	schema, err := gqlparser.LoadSchema(sources...)
+	if err != nil {
+		return nil, fmt.Errorf("parse SDL schema: %w", err)
+	}
+
+	graph := &Graph{Nodes: make(map[string]*Node), Roots: []string{}}
+
+	for name, def := range schema.Types {
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		switch def.Kind {
+		case ast.Object, ast.Interface, ast.InputObject:
+			graph.Nodes[name] = convertSDLType(def)
+		case ast.Union:
+			node := &Node{Name: name, Kind: string(ast.Union), Fields: []Edge{}}
+			node.PossibleTypes = append(node.PossibleTypes, def.Types...)
+			graph.Nodes[name] = node
+		case ast.Enum:
+			graph.Nodes[name] = &Node{Name: name, Kind: string(ast.Enum)}
+		case ast.Scalar:
+			graph.Nodes[name] = &Node{Name: name, Kind: string(ast.Scalar)}
+		}
+	}
+
+	// Wire up interface implementations now that every type is indexed, so
+	// PossibleTypes on the interface side mirrors the introspection/GitHub
+	// loaders (which report possibleTypes directly on the interface).
+	for name, def := range schema.Types {
+		if def.Kind != ast.Object {
+			continue
+		}
+		node, ok := graph.Nodes[name]
+		if !ok {
+			continue
+		}
+		for _, iface := range def.Interfaces {
+			node.Implements = append(node.Implements, iface)
+			if ifaceNode, ok := graph.Nodes[iface]; ok {
+				ifaceNode.PossibleTypes = append(ifaceNode.PossibleTypes, name)
+			}
+		}
+	}
+
+	if schema.Query != nil {
+		graph.Roots = append(graph.Roots, schema.Query.Name)
+	}
+	if includeMutations && schema.Mutation != nil {
+		graph.Roots = append(graph.Roots, schema.Mutation.Name)
+	}
+	if schema.Subscription != nil {
+		graph.Roots = append(graph.Roots, schema.Subscription.Name)
+	}
+
+	return graph, nil
+}
+
+func convertSDLType(def *ast.Definition) *Node {
+	node := &Node{
+		Name:   def.Name,
+		Kind:   string(def.Kind),
+		Fields: []Edge{},
+	}
+
+	for _, f := range def.Fields {
+		if strings.HasPrefix(f.Name, "__") {
+			continue
+		}
+
+		edge := Edge{
+			Name:   f.Name,
+			Target: cleanTypeName(formatSDLType(f.Type)),
+		}
+
+		for _, a := range f.Arguments {
+			arg := Arg{Name: a.Name, Type: formatSDLType(a.Type)}
+			if a.DefaultValue != nil {
+				arg.DefaultValue = a.DefaultValue.String()
+			}
+			edge.Arguments = append(edge.Arguments, arg)
+		}
+
+		for _, d := range f.Directives {
+			args := make(map[string]string, len(d.Arguments))
+			for _, a := range d.Arguments {
+				args[a.Name] = a.Value.Raw
+			}
+			edge.Directives = append(edge.Directives, Directive{Name: d.Name, Args: args})
+			if d.Name == "deprecated" {
+				edge.IsDeprecated = true
+				edge.DeprecationReason = args["reason"]
+			}
+		}
+
+		node.Fields = append(node.Fields, edge)
+	}
+
+	return node
+}
+
+// formatSDLType renders an *ast.Type as the bare SDL-style string (e.g.
+// "[User!]!"), matching the shape cleanTypeName expects elsewhere in the
+// package.
+func formatSDLType(t *ast.Type) string {
+	if t == nil {
+		return ""
+	}
+	if t.Elem != nil {
+		inner := formatSDLType(t.Elem)
+		if t.NonNull {
+			return "[" + inner + "]!"
+		}
+		return "[" + inner + "]"
+	}
+	if t.NonNull {
+		return t.NamedType + "!"
+	}
+	return t.NamedType
+}
@@ -5,24 +5,33 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lovepreet-se7en/graphql-enum/internal/generator"
+	"github.com/lovepreet-se7en/graphql-enum/internal/runner"
 	"github.com/lovepreet-se7en/graphql-enum/internal/schema"
 )
 
 type Model struct {
-	list          list.Model
-	paths         []schema.GraphQLPath
-	queries       map[int]generator.GeneratedQuery
-	selectedQuery generator.GeneratedQuery
-	showQuery     bool
-	width         int
-	height        int
-	schema        *schema.Schema
-	targetType    string
-	statusMsg     string
+	list              list.Model
+	paths             []schema.GraphQLPath
+	queries           map[int]generator.GeneratedQuery
+	selectedQuery     generator.GeneratedQuery
+	showQuery         bool
+	width             int
+	height            int
+	schema            *schema.Schema
+	targetType        string
+	statusMsg         string
+	endpoint          string
+	endpointHeaders   map[string]string
+	showResponse      bool
+	runningQuery      bool
+	selectedResult    runner.Result
+	relay             bool
+	includeDeprecated bool
+	emitConditional   bool
 }
 
-func NewModel(paths []schema.GraphQLPath, scm *schema.Schema, target string) Model {
-	gen := generator.New(scm, "/tmp")
+func NewModel(paths []schema.GraphQLPath, scm *schema.Schema, target string, relay, includeDeprecated, emitConditional bool) Model {
+	gen := generator.New(scm, "/tmp").WithRelay(relay).WithIncludeDeprecated(includeDeprecated).WithEmitConditional(emitConditional)
 	queries, _ := gen.GenerateAll(paths)
 	
 	items := make([]list.Item, len(paths))
@@ -57,14 +66,24 @@ func NewModel(paths []schema.GraphQLPath, scm *schema.Schema, target string) Mod
 	}
 	
 	return Model{
-		list:       l,
-		paths:      paths,
-		queries:    queryMap,
-		schema:     scm,
-		targetType: target,
+		list:              l,
+		paths:             paths,
+		queries:           queryMap,
+		schema:            scm,
+		targetType:        target,
+		relay:             relay,
+		includeDeprecated: includeDeprecated,
+		emitConditional:   emitConditional,
 	}
 }
 
 func (m Model) Init() tea.Cmd {
 	return nil
 }
+
+// SetEndpoint enables the response view: queries can be run live against
+// endpoint, using headers for auth (bearer tokens, cookies, etc).
+func (m *Model) SetEndpoint(endpoint string, headers map[string]string) {
+	m.endpoint = endpoint
+	m.endpointHeaders = headers
+}
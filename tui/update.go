@@ -1,15 +1,19 @@
 package tui
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/atotto/clipboard"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/lovepreet-se7en/graphql-enum/internal/generator"
-	"os"
-	"path/filepath"
+	"github.com/lovepreet-se7en/graphql-enum/internal/runner"
 )
 
 type statusMsg string
 
+type responseMsg runner.Result
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
@@ -19,14 +23,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 		
 	case tea.KeyMsg:
+		if m.showResponse {
+			return m.handleResponseViewKeys(msg)
+		}
 		if m.showQuery {
 			return m.handleQueryViewKeys(msg)
 		}
 		return m.handleListKeys(msg)
-		
+
 	case statusMsg:
 		m.statusMsg = string(msg)
 		return m, nil
+
+	case responseMsg:
+		m.runningQuery = false
+		m.selectedResult = runner.Result(msg)
+		m.showResponse = true
+		return m, nil
 	}
 	
 	var cmd tea.Cmd
@@ -67,16 +80,53 @@ func (m Model) handleQueryViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		filename := filepath.Join(".", m.selectedQuery.FileName)
 		os.WriteFile(filename, []byte(m.selectedQuery.Query), 0644)
 		return m, func() tea.Msg { return statusMsg("Saved to " + filename) }
+	case "r":
+		if m.endpoint == "" {
+			return m, func() tea.Msg { return statusMsg("No endpoint configured, pass --run <url>") }
+		}
+		m.runningQuery = true
+		return m, m.runSelected()
 	}
 	return m, nil
 }
 
+func (m Model) handleResponseViewKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc":
+		m.showResponse = false
+		return m, nil
+	case "c":
+		clipboard.WriteAll(string(m.selectedResult.Response))
+		return m, func() tea.Msg { return statusMsg("Copied response to clipboard!") }
+	}
+	return m, nil
+}
+
+// runSelected executes the currently selected query against the configured
+// endpoint and reports its result back as a responseMsg.
+func (m Model) runSelected() tea.Cmd {
+	query := m.selectedQuery
+	endpoint := m.endpoint
+	headers := m.endpointHeaders
+	targetType := m.targetType
+
+	return func() tea.Msg {
+		r := runner.New(runner.Config{
+			Endpoint:    endpoint,
+			Headers:     headers,
+			Concurrency: 1,
+		})
+		report := r.RunAll([]generator.GeneratedQuery{query}, targetType)
+		return responseMsg(report.Results[0])
+	}
+}
+
 func (m Model) saveAll() tea.Cmd {
 	var gen *generator.Generator
 	var queries []generator.GeneratedQuery
 
 	return func() tea.Msg {
-		gen = generator.New(m.schema, "./queries")
+		gen = generator.New(m.schema, "./queries").WithRelay(m.relay).WithIncludeDeprecated(m.includeDeprecated).WithEmitConditional(m.emitConditional)
 		queries = make([]generator.GeneratedQuery, 0, len(m.queries))
 		for _, q := range m.queries {
 			queries = append(queries, q)
@@ -27,6 +27,9 @@ func (i item) Description() string { return i.description }
 func (i item) FilterValue() string { return i.title + i.description }
 
 func (m Model) View() string {
+	if m.showResponse {
+		return m.renderResponseView()
+	}
 	if m.showQuery {
 		return m.renderQueryView()
 	}
@@ -61,12 +64,50 @@ func (m Model) renderQueryView() string {
 		b.WriteString("\n")
 	}
 	
-	b.WriteString(helpStyle.Render("c: copy • s: save • q: back • ctrl+c: quit"))
-	
+	if m.runningQuery {
+		b.WriteString(helpStyle.Render("running query..."))
+	} else {
+		b.WriteString(helpStyle.Render("c: copy • s: save • r: run live • q: back • ctrl+c: quit"))
+	}
+
 	if m.statusMsg != "" {
 		b.WriteString("\n")
 		b.WriteString(statusMessageStyle.Render(m.statusMsg))
 	}
-	
+
+	return b.String()
+}
+
+func (m Model) renderResponseView() string {
+	var b strings.Builder
+	res := m.selectedResult
+
+	b.WriteString(titleStyle.Render("Live Response"))
+	b.WriteString("\n\n")
+
+	status := fmt.Sprintf("status: %d   latency: %dms   attempts: %d   target reached: %v",
+		res.Status, res.LatencyMs, res.Attempts, res.TargetReached)
+	b.WriteString(status)
+	b.WriteString("\n\n")
+
+	if res.TransportErr != "" {
+		b.WriteString(statusMessageStyle.Copy().Foreground(lipgloss.Color("#FF0000")).Render("transport error: " + res.TransportErr))
+		b.WriteString("\n\n")
+	}
+
+	if len(res.Errors) > 0 {
+		b.WriteString(lipgloss.NewStyle().Bold(true).Render("GraphQL errors:"))
+		b.WriteString("\n")
+		for _, e := range res.Errors {
+			b.WriteString("  - " + e.Message + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(queryStyle.Render(string(res.Response)))
+	b.WriteString("\n\n")
+
+	b.WriteString(helpStyle.Render("c: copy response • q: back • ctrl+c: quit"))
+
 	return b.String()
 }